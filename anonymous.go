@@ -0,0 +1,42 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrAnonymous is returned for operations links2 doesn't allow when run with
+// WithAnonymous, like saving documents and reading local files.
+var ErrAnonymous = errors.New("not allowed in anonymous mode")
+
+// WithAnonymous runs links2 -anonymous, which forbids local file access,
+// downloads and changes to associations, for rendering untrusted URLs.
+// Operations which need what's forbidden, including those built on saving
+// the document like PageText, return ErrAnonymous instead of waiting for
+// dialogs links2 won't show.
+func WithAnonymous() Option {
+	return func(o *options) error {
+		o.anonymous = true
+		o.args = append(o.args, "-anonymous")
+		return nil
+	}
+}
+
+// Anonymous reports whether the browser was opened with WithAnonymous.
+func (b *Browser) Anonymous() bool {
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.anonymous()
+}
+
+func (b *Browser) anonymous() bool { return b.opts != nil && b.opts.anonymous }
+
+// checkAnonymous returns an error wrapping ErrAnonymous for operation op if
+// the browser runs in anonymous mode.
+func (b *Browser) checkAnonymous(op string) error {
+	if b.anonymous() {
+		return fmt.Errorf("%s: %w", op, ErrAnonymous)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package links2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WithAsciicast records the session to w in the asciicast v2 format of
+// asciinema, for playback with "asciinema play". The output read from
+// links2, the keys sent and terminal resizes are recorded with their time.
+// The recording spans restarts by WithRestart. Keys sent to a Console of
+// WithConsole or WithTmux are not recorded. Write errors stop the recording.
+func WithAsciicast(w io.Writer) Option {
+	return func(o *options) error {
+		o.asciicast = w
+		return nil
+	}
+}
+
+// asciicast writes an asciicast v2 recording: a header line followed by
+// one event line [time, code, data] for each write.
+type asciicast struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+	err   error
+	// partial holds the start of a rune split across output writes, as
+	// event data must be valid UTF-8.
+	partial []byte
+}
+
+func newAsciicast(w io.Writer, cols, rows int) *asciicast {
+	c := &asciicast{w: w, start: time.Now()}
+	header := struct {
+		Version   int               `json:"version"`
+		Width     int               `json:"width"`
+		Height    int               `json:"height"`
+		Timestamp int64             `json:"timestamp"`
+		Env       map[string]string `json:"env,omitempty"`
+	}{
+		Version:   2,
+		Width:     cols,
+		Height:    rows,
+		Timestamp: c.start.Unix(),
+	}
+	if term := os.Getenv("TERM"); term != "" {
+		header.Env = map[string]string{"TERM": term}
+	}
+	c.writeLine(header)
+	return c
+}
+
+// Write records output read from links2.
+func (c *asciicast) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	buf := append(c.partial, p...)
+	n := len(buf)
+	// Hold back an incomplete rune at the end.
+	for i := 1; i < utf8.UTFMax && i <= len(buf); i++ {
+		if utf8.RuneStart(buf[n-i]) {
+			if !utf8.FullRune(buf[n-i:]) {
+				n -= i
+			}
+			break
+		}
+	}
+	c.partial = append([]byte(nil), buf[n:]...)
+	if n > 0 {
+		c.event("o", string(buf[:n]))
+	}
+	return len(p), nil
+}
+
+// castInput records keys sent to links2 by WithAsciicast. It's a go-expect
+// send observer.
+func (b *Browser) castInput(keys string, n int, err error) {
+	b.cast.input(keys, err)
+}
+
+func (c *asciicast) input(keys string, err error) {
+	if c == nil || err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.event("i", keys)
+}
+
+// resize records a resize of the terminal.
+func (c *asciicast) resize(cols, rows int) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+func (c *asciicast) event(code, data string) {
+	t := time.Since(c.start).Seconds()
+	c.writeLine([]any{t, code, data})
+}
+
+func (c *asciicast) writeLine(v any) {
+	if c.err != nil {
+		return
+	}
+	line, err := json.Marshal(v)
+	if err != nil {
+		c.err = err
+		return
+	}
+	_, c.err = c.w.Write(append(line, '\n'))
+}
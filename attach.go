@@ -0,0 +1,223 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Netflix/go-expect"
+)
+
+// Attach drives a links2 already running in the tmux pane target, e.g.
+// "main:1.0", instead of starting one, so a human watching the pane can
+// co-drive it. Keys are typed with tmux send-keys and output is read with
+// tmux pipe-pane, starting from the current contents of the pane.
+//
+// Close stops driving the pane but leaves links2 running. Wait and Exited
+// don't report when the attached links2 exits.
+func (b *Browser) Attach(target string, opts ...Option) error {
+	return b.AttachContext(context.Background(), target, opts...)
+}
+
+// AttachContext is like Attach but bounds the tmux commands run by ctx.
+func (b *Browser) AttachContext(ctx context.Context, target string, opts ...Option) error {
+	return b.OpenContext(ctx, append(opts[:len(opts):len(opts)], WithConsole(func(stdout io.Writer) (Console, error) {
+		return attachTmux(ctx, target, stdout)
+	}))...)
+}
+
+// WithTmuxSession runs links2 in a new detached tmux session with the given
+// name rather than on a terminal of the Browser, so it can outlive the
+// program: Detach stops driving it and Attach(name) drives it again.
+// Close kills the session.
+func WithTmuxSession(name string) Option {
+	return func(o *options) error {
+		o.tmuxSession = name
+		return nil
+	}
+}
+
+// Detach stops driving links2 but leaves it running in its tmux pane, and
+// returns the pane to Attach to later. Only browsers opened with
+// WithTmuxSession or Attach can be detached; others return an error
+// wrapping errors.ErrUnsupported. The temporary home of WithConfig is left
+// in place for the detached links2.
+func (b *Browser) Detach() (target string, err error) {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.s == stateUndefined {
+		return "", ErrNotStarted
+	}
+	tc, ok := b.c.(*tmuxConsole)
+	if !ok {
+		return "", fmt.Errorf("detach: %w", errors.ErrUnsupported)
+	}
+	err = tc.detach()
+	b.events.close()
+	b.instance = instance{}
+	return tc.pane, err
+}
+
+// tmuxConsole is a Console driving a tmux pane.
+type tmuxConsole struct {
+	*expect.Console
+	pane    string // pane is the tmux pane id, e.g. "%3".
+	session string // session is the tmux session started for links2, if any.
+	dir     string // dir holds the file output is piped to.
+	tail    *tailReader
+}
+
+// startTmux starts cmd in a new detached tmux session and attaches to it.
+func startTmux(ctx context.Context, session string, cmd *exec.Cmd, cols, rows int, stdout io.Writer) (*tmuxConsole, error) {
+	args := []string{"new-session", "-d", "-s", session, "-x", strconv.Itoa(cols), "-y", strconv.Itoa(rows)}
+	for _, env := range cmd.Env {
+		if strings.HasPrefix(env, "HOME=") {
+			args = append(args, "-e", env)
+		}
+	}
+	args = append(append(args, "--"), cmd.Args...)
+	if _, err := tmux(ctx, args...); err != nil {
+		return nil, err
+	}
+	c, err := attachTmux(ctx, session, stdout)
+	if err != nil {
+		tmux(context.Background(), "kill-session", "-t", session)
+		return nil, err
+	}
+	c.session = session
+	return c, nil
+}
+
+// attachTmux pipes the output of the tmux pane target to a new console.
+func attachTmux(ctx context.Context, target string, stdout io.Writer) (_ *tmuxConsole, err error) {
+	out, err := tmux(ctx, "display-message", "-p", "-t", target, "#{pane_id}")
+	if err != nil {
+		return nil, err
+	}
+	pane := strings.TrimSpace(out)
+	dir, err := os.MkdirTemp("", "links2-tmux-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+	path := filepath.Join(dir, "output")
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	// Start piping before capturing so no output is missed in between.
+	if _, err := tmux(ctx, "pipe-pane", "-O", "-t", pane, "cat >> "+shellQuote(path)); err != nil {
+		f.Close()
+		return nil, err
+	}
+	screen, err := tmux(ctx, "capture-pane", "-p", "-e", "-t", pane)
+	if err != nil {
+		f.Close()
+		tmux(context.Background(), "pipe-pane", "-t", pane)
+		return nil, err
+	}
+	tail := &tailReader{f: f, done: make(chan struct{})}
+	initial := "\033[H\033[2J" + strings.ReplaceAll(strings.TrimRight(screen, "\n"), "\n", "\r\n")
+	c, err := expect.NewConsole(
+		expect.WithStdin(io.MultiReader(strings.NewReader(initial), tail)),
+		expect.WithStdout(stdout),
+	)
+	if err != nil {
+		tail.Close()
+		tmux(context.Background(), "pipe-pane", "-t", pane)
+		return nil, err
+	}
+	return &tmuxConsole{Console: c, pane: pane, dir: dir, tail: tail}, nil
+}
+
+// Send types s into the pane.
+func (c *tmuxConsole) Send(s string) (int, error) {
+	if _, err := tmux(context.Background(), "send-keys", "-t", c.pane, "-l", "--", s); err != nil {
+		return 0, err
+	}
+	return len(s), nil
+}
+
+// Tty returns nil since links2 runs on the pane's terminal.
+func (c *tmuxConsole) Tty() *os.File { return nil }
+
+// Close stops piping the pane's output. It kills the session started by
+// startTmux but otherwise leaves links2 running.
+func (c *tmuxConsole) Close() error {
+	err := c.detach()
+	if c.session != "" {
+		if _, err1 := tmux(context.Background(), "kill-session", "-t", c.session); err == nil {
+			err = err1
+		}
+	}
+	return err
+}
+
+// detach stops piping the pane's output.
+func (c *tmuxConsole) detach() error {
+	_, err := tmux(context.Background(), "pipe-pane", "-t", c.pane)
+	c.tail.Close()
+	if err1 := c.Console.Close(); err == nil {
+		err = err1
+	}
+	os.RemoveAll(c.dir)
+	return err
+}
+
+// tmux runs a tmux command and returns its output.
+func tmux(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "tmux", args...).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("tmux %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// shellQuote quotes s for sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// tailReader reads a file which is being appended to, like tail -f, until
+// it's closed.
+type tailReader struct {
+	f    *os.File
+	done chan struct{}
+}
+
+func (t *tailReader) Read(p []byte) (int, error) {
+	for {
+		n, err := t.f.Read(p)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+		select {
+		case <-t.done:
+			return 0, io.EOF
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (t *tailReader) Close() error {
+	select {
+	case <-t.done:
+	default:
+		close(t.done)
+	}
+	return t.f.Close()
+}
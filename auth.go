@@ -0,0 +1,101 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+const authDialog = "Authorization required \033[0;7m"
+
+// Credentials returns the user name and password for the HTTP authentication
+// realm of host, or ok false to cancel the prompt.
+//
+// It's called while links2 shows the prompt, by the operation loading the
+// page, with the context of the operation. The operation holds the Browser,
+// so Credentials must not call methods of the Browser; ctx is done when the
+// operation is canceled, e.g. to stop asking a user.
+type Credentials func(ctx context.Context, realm, host string) (user, pass string, ok bool)
+
+// WithCredentials answers the username/password dialogs links2 shows for
+// pages requiring HTTP authentication. Without it, such pages fail with
+// ErrAuthRequired.
+func WithCredentials(f Credentials) Option {
+	return func(o *options) error {
+		o.credentials = f
+		return nil
+	}
+}
+
+// prompt is a dialog which may interrupt a page load.
+type prompt struct {
+	pattern string
+	// answer answers the dialog once its title is drawn. On success
+	// loading resumes.
+	answer func(res *NavigateResult) error
+}
+
+// prompts returns the dialogs expectLoaded answers.
+func (b *Browser) prompts() []prompt {
+	return []prompt{
+		{b.tr(authDialog), b.answerAuth},
+		{b.tr(certDialog), b.answerCert},
+		{b.tr(unknownTypeDialog), b.answerContent},
+		{b.tr(whatToDoDialog), b.answerContent},
+	}
+}
+
+// answerAuth fills in the authentication dialog, or cancels it.
+func (b *Browser) answerAuth(res *NavigateResult) error {
+	body, err := b.expectString(okButton)
+	if err != nil {
+		return err
+	}
+	realm := quoted(strings.Join(dialogLines(body), " "))
+	host := ""
+	if u, err := url.Parse(res.urlString()); err == nil {
+		host = u.Host
+	}
+	var (
+		user, pass string
+		ok         bool
+	)
+	if b.opts.credentials != nil {
+		ctx := b.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		user, pass, ok = b.opts.credentials(ctx, realm, host)
+	}
+	if ok {
+		if err := checkInput(user + pass); err != nil {
+			ok = false
+		}
+	}
+	if !ok {
+		b.c.Send("\033") // Esc
+		return fmt.Errorf("%w: realm %q", ErrAuthRequired, realm)
+	}
+	// Focus starts on the user name. Esc afterwards signals the load
+	// finished as in Navigate. The credentials are written rather than
+	// sent if possible so send observers don't log or record them.
+	keys := "\025" + user + "\t\025" + pass + "\n\033" // ^U, Tab
+	if w, ok := b.c.(io.Writer); ok {
+		io.WriteString(w, keys)
+	} else {
+		b.c.Send(keys)
+	}
+	return nil
+}
+
+// quoted returns the first double quoted string in s, or "".
+func quoted(s string) string {
+	_, s, ok := strings.Cut(s, `"`)
+	if !ok {
+		return ""
+	}
+	s, _, _ = strings.Cut(s, `"`)
+	return s
+}
@@ -0,0 +1,99 @@
+package links2
+
+import (
+	"context"
+	"regexp"
+	"time"
+)
+
+// DownloadBatch is a set of downloads started together, e.g. by
+// DownloadAllMatching, whose progress is reported as a whole.
+type DownloadBatch struct {
+	b         *Browser
+	Downloads []*Download
+}
+
+// BatchStatus is the progress of a DownloadBatch.
+type BatchStatus struct {
+	Downloads int   // Downloads is the number of downloads in the batch.
+	Done      int   // Done is the number of downloads completed or cancelled.
+	Received  int64 // Received is the number of bytes received by all downloads.
+	Total     int64 // Total is the size of all downloads in bytes or -1 if any is unknown.
+}
+
+// DownloadAllMatching downloads the target of each link of the current
+// document whose URL matches re to dir with DownloadURL, naming the files
+// after the URLs. It returns the downloads started, stopping at the first one
+// which fails.
+func (b *Browser) DownloadAllMatching(re *regexp.Regexp, dir string) (*DownloadBatch, error) {
+	return b.DownloadAllMatchingContext(context.Background(), re, dir)
+}
+
+// DownloadAllMatchingContext is like DownloadAllMatching but bounds waits by ctx.
+func (b *Browser) DownloadAllMatchingContext(ctx context.Context, re *regexp.Regexp, dir string) (*DownloadBatch, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	links, err := b.LinksContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var matching []Link
+	for _, l := range links {
+		if re.MatchString(l.URL) {
+			matching = append(matching, l)
+		}
+	}
+	downloads, err := b.downloadAll(ctx, matching, dir)
+	return &DownloadBatch{b: b, Downloads: downloads}, err
+}
+
+// Progress refreshes and returns the status of the batch.
+func (d *DownloadBatch) Progress() (BatchStatus, error) {
+	return d.ProgressContext(context.Background())
+}
+
+// ProgressContext is like Progress but bounds waits by ctx.
+func (d *DownloadBatch) ProgressContext(ctx context.Context) (BatchStatus, error) {
+	s := BatchStatus{Downloads: len(d.Downloads)}
+	if len(d.Downloads) == 0 {
+		return s, nil
+	}
+	if _, err := d.b.DownloadsContext(ctx); err != nil {
+		return BatchStatus{}, err
+	}
+	for _, dl := range d.Downloads {
+		st := dl.status
+		if st.Done {
+			s.Done++
+		}
+		s.Received += st.Received
+		switch {
+		case st.Total < 0 || s.Total < 0:
+			s.Total = -1
+		default:
+			s.Total += st.Total
+		}
+	}
+	return s, nil
+}
+
+// Wait polls the status of the batch until all downloads complete or ctx is
+// done.
+func (d *DownloadBatch) Wait(ctx context.Context, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		s, err := d.ProgressContext(ctx)
+		if err != nil {
+			return err
+		}
+		if s.Done == s.Downloads {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	bookmarkManager = "Bookmark manager \033[0;7m"
+	addBookmark     = "Add bookmark \033[0;7m"
+)
+
+// bookmarkAdd is the position of the Add button of the bookmark manager in
+// tab order after the bookmark list, following Goto, Edit, Delete and Add
+// folder.
+const bookmarkAdd = 4
+
+// Bookmark is an entry listed in the bookmark manager.
+// See the bookmarks subpackage for reading the bookmarks file directly.
+type Bookmark struct {
+	Title  string
+	Folder bool
+}
+
+// openBookmarkManager opens the bookmark manager and returns the raw output
+// drawing it.
+func (b *Browser) openBookmarkManager() (string, error) {
+	if err := b.perform(ActionBookmarks); err != nil {
+		return "", err
+	}
+	b.s = stateMenu
+	b.menuName = menuBookmarks
+	if _, err := b.expectString(bookmarkManager); err != nil {
+		return "", err
+	}
+	return b.drain()
+}
+
+// pressButton focuses the i-th button of the open dialog and presses it.
+// The first widget of the dialog is assumed to precede the buttons.
+func (b *Browser) pressButton(i int) {
+	b.c.Send(strings.Repeat("\t", i+1) + "\n") // Tab, Enter
+}
+
+// AddBookmark bookmarks the current document with the given title.
+func (b *Browser) AddBookmark(title string) error {
+	return b.AddBookmarkContext(context.Background(), title)
+}
+
+// AddBookmarkContext is like AddBookmark but bounds waits by ctx.
+func (b *Browser) AddBookmarkContext(ctx context.Context, title string) error {
+	_, done := b.begin(ctx)
+	defer done()
+	if err := checkInput(title); err != nil {
+		return err
+	}
+	defer b.closeMenu()
+	if _, err := b.openBookmarkManager(); err != nil {
+		return err
+	}
+	b.pressButton(bookmarkAdd)
+	if _, err := b.expectString(addBookmark); err != nil {
+		return err
+	}
+	// The URL field is already filled in with the current document.
+	// Replace the name and submit the dialog.
+	b.c.Send("\025" + title + "\n") // ^U
+	return nil
+}
+
+// Bookmarks returns the bookmarks listed in the bookmark manager.
+// Only the bookmarks which fit in the bookmark manager are listed.
+func (b *Browser) Bookmarks() ([]Bookmark, error) {
+	return b.BookmarksContext(context.Background())
+}
+
+// BookmarksContext is like Bookmarks but bounds waits by ctx.
+func (b *Browser) BookmarksContext(ctx context.Context) ([]Bookmark, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	defer b.closeMenu()
+	raw, err := b.openBookmarkManager()
+	if err != nil {
+		return nil, err
+	}
+	var bookmarks []Bookmark
+	for _, line := range dialogLines(raw) {
+		if strings.HasPrefix(line, "[ ") {
+			// Buttons follow the list.
+			break
+		}
+		switch {
+		case strings.HasPrefix(line, "+ "), strings.HasPrefix(line, "- "):
+			bookmarks = append(bookmarks, Bookmark{Title: line[2:], Folder: true})
+		default:
+			bookmarks = append(bookmarks, Bookmark{Title: line})
+		}
+	}
+	return bookmarks, nil
+}
+
+// GoToBookmark navigates to the i-th bookmark listed in the bookmark manager.
+func (b *Browser) GoToBookmark(i int) (NavigateResult, error) {
+	return b.GoToBookmarkContext(context.Background(), i)
+}
+
+// GoToBookmarkContext is like GoToBookmark but bounds waits by ctx.
+func (b *Browser) GoToBookmarkContext(ctx context.Context, i int) (NavigateResult, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	if i < 0 {
+		return NavigateResult{}, fmt.Errorf("invalid bookmark index: %d", i)
+	}
+	if _, err := b.openBookmarkManager(); err != nil {
+		return NavigateResult{}, err
+	}
+	// Select the bookmark and Goto, ending with Esc as in Navigate.
+	b.s = stateIdle
+	b.menuName = ""
+	res := NavigateResult{Start: time.Now()}
+	err := b.expectLoad("\033[H"+strings.Repeat("\033[B", i)+"\n\033", &res) // Home, Down, Enter, Esc
+	res.End = time.Now()
+	if err != nil {
+		return res, fmt.Errorf("go to bookmark %d: %w", i, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,157 @@
+// Package bookmarks reads and writes the links2 bookmarks file.
+//
+// Links2 stores bookmarks as a Netscape-style HTML file:
+//
+//	<DL><P>
+//	    <DT><A HREF="https://example.com/">Example</A>
+//	    <DT><H3>Folder</H3>
+//	    <DL><P>
+//	        <DT><A HREF="https://example.org/">Nested</A>
+//	    </DL><P>
+//	</DL><P>
+package bookmarks
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Bookmark is a bookmark or, if it has no URL, a folder of bookmarks.
+type Bookmark struct {
+	Title    string
+	URL      string
+	Children []Bookmark
+}
+
+// Folder reports whether b is a folder.
+func (b Bookmark) Folder() bool { return b.URL == "" }
+
+// DefaultPath returns the path of the bookmarks file of the current user.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".links", "bookmarks.html"), nil
+}
+
+var (
+	linkRE   = regexp.MustCompile(`(?i)<DT><A HREF="([^"]*)"[^>]*>(.*)</A>`)
+	folderRE = regexp.MustCompile(`(?i)<DT><H3[^>]*>(.*)</H3>`)
+	openRE   = regexp.MustCompile(`(?i)^<DL>`)
+	closeRE  = regexp.MustCompile(`(?i)^</DL>`)
+)
+
+// Read parses a bookmarks file.
+func Read(r io.Reader) ([]Bookmark, error) {
+	// stack holds the children of each open folder, with the root at the bottom.
+	stack := [][]Bookmark{nil}
+	var pending *Bookmark // folder waiting for its <DL>
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		s := strings.TrimSpace(sc.Text())
+		top := len(stack) - 1
+		if pending != nil && !openRE.MatchString(s) {
+			// An empty folder written without a list.
+			stack[top] = append(stack[top], *pending)
+			pending = nil
+		}
+		switch {
+		case linkRE.MatchString(s):
+			m := linkRE.FindStringSubmatch(s)
+			stack[top] = append(stack[top], Bookmark{
+				Title: html.UnescapeString(m[2]),
+				URL:   html.UnescapeString(m[1]),
+			})
+		case folderRE.MatchString(s):
+			m := folderRE.FindStringSubmatch(s)
+			pending = &Bookmark{Title: html.UnescapeString(m[1])}
+		case openRE.MatchString(s):
+			if pending == nil {
+				if top > 0 {
+					return nil, fmt.Errorf("bookmarks: line %d: list without folder", line)
+				}
+				continue
+			}
+			stack[top] = append(stack[top], *pending)
+			stack = append(stack, nil)
+			pending = nil
+		case closeRE.MatchString(s):
+			if top == 0 {
+				continue
+			}
+			children := stack[top]
+			stack = stack[:top]
+			parent := stack[top-1]
+			parent[len(parent)-1].Children = children
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("bookmarks: unterminated folder")
+	}
+	return stack[0], nil
+}
+
+// Write writes bookmarks in the links2 bookmarks file format.
+func Write(w io.Writer, bookmarks []Bookmark) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, `<HTML>
+<HEAD>
+<!--This is an automatically generated file.
+It will be read and overwritten.
+Do Not Edit! -->
+<TITLE>Links bookmarks</TITLE>
+</HEAD>
+<H1>Links bookmarks</H1>
+
+`)
+	writeList(bw, bookmarks, 0)
+	fmt.Fprint(bw, "</HTML>\n")
+	return bw.Flush()
+}
+
+func writeList(w io.Writer, bookmarks []Bookmark, depth int) {
+	indent := strings.Repeat("    ", depth)
+	fmt.Fprintf(w, "%s<DL><P>\n", indent)
+	for _, b := range bookmarks {
+		if b.Folder() {
+			fmt.Fprintf(w, "%s    <DT><H3>%s</H3>\n", indent, html.EscapeString(b.Title))
+			writeList(w, b.Children, depth+1)
+			continue
+		}
+		fmt.Fprintf(w, "%s    <DT><A HREF=\"%s\">%s</A>\n", indent, html.EscapeString(b.URL), html.EscapeString(b.Title))
+	}
+	fmt.Fprintf(w, "%s</DL><P>\n", indent)
+}
+
+// Load reads the bookmarks file at path.
+func Load(path string) ([]Bookmark, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Save writes bookmarks to the file at path, replacing it.
+func Save(path string, bookmarks []Bookmark) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, bookmarks); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
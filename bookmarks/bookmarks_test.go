@@ -0,0 +1,116 @@
+package bookmarks
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     []Bookmark
+	}{{
+		name: "flat",
+		in: `<DL><P>
+    <DT><A HREF="https://example.com/">Example</A>
+    <dt><a href="https://example.org/?a=1&amp;b=2" ADD_DATE="0">Q &amp; A</a>
+</DL><P>`,
+		want: []Bookmark{
+			{Title: "Example", URL: "https://example.com/"},
+			{Title: "Q & A", URL: "https://example.org/?a=1&b=2"},
+		},
+	}, {
+		name: "nested",
+		in: `<H1>Links bookmarks</H1>
+<DL><P>
+    <DT><H3>Folder</H3>
+    <DL><P>
+        <DT><A HREF="https://example.org/">Nested</A>
+        <DT><H3>Inner</H3>
+        <DL><P>
+            <DT><A HREF="https://example.net/">Deep</A>
+        </DL><P>
+    </DL><P>
+    <DT><A HREF="https://example.com/">After</A>
+</DL><P>`,
+		want: []Bookmark{
+			{Title: "Folder", Children: []Bookmark{
+				{Title: "Nested", URL: "https://example.org/"},
+				{Title: "Inner", Children: []Bookmark{{Title: "Deep", URL: "https://example.net/"}}},
+			}},
+			{Title: "After", URL: "https://example.com/"},
+		},
+	}, {
+		name: "empty folder",
+		in: `<DL><P>
+    <DT><H3>Empty</H3>
+    <DT><A HREF="https://example.com/">Example</A>
+</DL><P>`,
+		want: []Bookmark{
+			{Title: "Empty"},
+			{Title: "Example", URL: "https://example.com/"},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tc.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Read = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadError(t *testing.T) {
+	for _, in := range []string{
+		"<DL><P>\n<DT><H3>Open</H3>\n<DL><P>\n",
+		"<DL><P>\n<DT><H3>F</H3>\n<DL><P>\n<DL><P>\n</DL><P>\n</DL><P>\n",
+	} {
+		if b, err := Read(strings.NewReader(in)); err == nil {
+			t.Errorf("Read(%q) = %+v", in, b)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := []Bookmark{
+		{Title: `<b>"quoted"</b> & more`, URL: `https://example.com/?q="x"&y=<z>`},
+		{Title: "Folder", Children: []Bookmark{
+			{Title: "Nested", URL: "https://example.org/"},
+			{Title: "Inner", Children: []Bookmark{{Title: "Deep", URL: "https://example.net/"}}},
+		}},
+		{Title: "Last", URL: "file:///tmp/a.html"},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.html")
+	want := []Bookmark{{Title: "Example", URL: "https://example.com/"}}
+	if err := Save(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load = %+v, want %+v", got, want)
+	}
+}
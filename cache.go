@@ -0,0 +1,84 @@
+package links2
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ajzaff/links2/config"
+)
+
+// FlushCaches drops the cached and formatted documents links2 holds
+// (File→Flush all caches), so documents are fetched again when next loaded.
+func (b *Browser) FlushCaches() error {
+	return b.FlushCachesContext(context.Background())
+}
+
+// FlushCachesContext is like FlushCaches but bounds waits by ctx.
+func (b *Browser) FlushCachesContext(ctx context.Context) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.OpenMenuContext(ctx, "File", "Flush all caches"); err != nil {
+		return err
+	}
+	b.s = stateIdle
+	b.menuName = ""
+	return nil
+}
+
+// ReloadNoCache flushes the caches and reloads the current document, so it's
+// fetched from the network instead of being served from the cache.
+func (b *Browser) ReloadNoCache() error {
+	return b.ReloadNoCacheContext(context.Background())
+}
+
+// ReloadNoCacheContext is like ReloadNoCache but bounds waits by ctx.
+func (b *Browser) ReloadNoCacheContext(ctx context.Context) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.FlushCachesContext(ctx); err != nil {
+		return err
+	}
+	return b.ReloadContext(ctx)
+}
+
+// CacheSettings are the sizes of the Setup→Cache dialog. Zero fields are
+// left unchanged.
+type CacheSettings struct {
+	MemoryCacheKiB     int // MemoryCacheKiB is the size of the document cache in KiB.
+	FormattedDocuments int // FormattedDocuments is the number of formatted documents kept.
+}
+
+// SetCacheSettings sets the cache sizes of the running links2.
+// Use SaveSettings to persist them.
+func (b *Browser) SetCacheSettings(cs CacheSettings) error {
+	return b.SetCacheSettingsContext(context.Background(), cs)
+}
+
+// SetCacheSettingsContext is like SetCacheSettings but bounds waits by ctx.
+func (b *Browser) SetCacheSettingsContext(ctx context.Context, cs CacheSettings) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.openDialog(ctx, "Setup", "Cache"); err != nil {
+		return err
+	}
+	return b.setControls(nil, itoaField(cs.MemoryCacheKiB), itoaField(cs.FormattedDocuments))
+}
+
+// Configure sets the options of cs in c, e.g. for WithConfig.
+func (cs CacheSettings) Configure(c *config.Config) {
+	if cs.MemoryCacheKiB > 0 {
+		c.Set("memory_cache_size", strconv.Itoa(cs.MemoryCacheKiB*1024)) // Bytes.
+	}
+	if cs.FormattedDocuments > 0 {
+		c.Set("formatted_document_cache_size", strconv.Itoa(cs.FormattedDocuments))
+	}
+}
+
+// itoaField returns the text field value of n, or "" to leave the field
+// unchanged if n is not positive.
+func itoaField(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,29 @@
+package links2
+
+import (
+	"context"
+	"io"
+)
+
+// WithOutputCapture copies the raw output read from links2 to w and keeps
+// its tail for LastOutput. w may be nil to only keep the tail.
+func WithOutputCapture(w io.Writer) Option {
+	return func(o *options) error {
+		o.capture, o.captureOut = true, w
+		return nil
+	}
+}
+
+// LastOutput returns up to the last n bytes of raw output read from links2
+// with WithOutputCapture, e.g. to see what links2 drew before an operation
+// failed with ErrTimeout. The last 64 KiB are kept. Like Stderr, the output
+// of the last process is kept by Close.
+func (b *Browser) LastOutput(n int) string {
+	_, done := b.begin(context.Background())
+	defer done()
+	s := b.proc.output.String()
+	if n >= 0 && n < len(s) {
+		s = s[len(s)-n:]
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package links2
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	certDialog = "Certificate error \033[0;7m"
+	certAccept = "[ Accept ]"
+	certReject = "[ Reject ]"
+)
+
+// fingerprintPattern matches a hex fingerprint like "AB:CD:...".
+var fingerprintPattern = regexp.MustCompile(`[0-9A-Fa-f]{2}(?::[0-9A-Fa-f]{2}){15,}`)
+
+// CertPolicy decides whether to accept the invalid certificate of host,
+// given its fingerprint as shown by links2.
+type CertPolicy func(host, fingerprint string) bool
+
+// Certificate policies.
+var (
+	RejectInvalidCerts CertPolicy = func(host, fingerprint string) bool { return false }
+	AcceptInvalidCerts CertPolicy = func(host, fingerprint string) bool { return true }
+)
+
+// WithCertPolicy answers the invalid certificate dialog with p. By default
+// invalid certificates are rejected and the load fails with ErrSSLFailure.
+func WithCertPolicy(p CertPolicy) Option {
+	return func(o *options) error {
+		o.certPolicy = p
+		return nil
+	}
+}
+
+// answerCert accepts or rejects an invalid certificate.
+func (b *Browser) answerCert(res *NavigateResult) error {
+	body, err := b.expectString(certReject)
+	if err != nil {
+		return err
+	}
+	lines := dialogLines(body)
+	fingerprint := fingerprintPattern.FindString(strings.Join(lines, " "))
+	host := ""
+	if u, err := url.Parse(res.urlString()); err == nil {
+		host = u.Host
+	}
+	policy := RejectInvalidCerts
+	if b.opts.certPolicy != nil {
+		policy = b.opts.certPolicy
+	}
+	if !policy(host, fingerprint) {
+		b.c.Send("\033") // Esc rejects.
+		return fmt.Errorf("%w: invalid certificate for %s", ErrSSLFailure, host)
+	}
+	if err := b.selectMenuItem(1, certAccept, ActionNextControl); err != nil {
+		b.c.Send("\033") // Esc
+		return fmt.Errorf("accept certificate: %w", err)
+	}
+	// Esc afterwards signals the load finished as in Navigate.
+	b.c.Send("\n\033")
+	return nil
+}
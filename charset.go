@@ -0,0 +1,82 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+)
+
+const defaultCodepage = "Default codepage"
+
+// SetDisplayCharset sets the character set of the terminal (Setup→Character
+// set), e.g. "Unicode UTF-8" or "ISO-8859-1". The name is matched against the
+// start of the menu item.
+func (b *Browser) SetDisplayCharset(name string) error {
+	return b.SetDisplayCharsetContext(context.Background(), name)
+}
+
+// SetDisplayCharsetContext is like SetDisplayCharset but bounds waits by ctx.
+func (b *Browser) SetDisplayCharsetContext(ctx context.Context, name string) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.OpenMenuContext(ctx, "Setup", "Character set", name); err != nil {
+		return err
+	}
+	b.s = stateIdle
+	b.menuName = ""
+	return nil
+}
+
+// SetAssumeCharset sets the character set assumed for documents which do not
+// declare one (the default codepage of Setup→HTML options).
+func (b *Browser) SetAssumeCharset(name string) error {
+	return b.SetAssumeCharsetContext(context.Background(), name)
+}
+
+// SetAssumeCharsetContext is like SetAssumeCharset but bounds waits by ctx.
+func (b *Browser) SetAssumeCharsetContext(ctx context.Context, name string) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.openDialog(ctx, "Setup", "HTML options"); err != nil {
+		return err
+	}
+	if err := b.selectMenuItem(1, defaultCodepage, ActionNextControl); err != nil {
+		b.closeMenu()
+		return fmt.Errorf("set assume charset: %w", err)
+	}
+	// Select opens the codepage list.
+	if err := b.press(ActionSelect); err != nil {
+		return err
+	}
+	if err := b.selectMenuItem(1, name, ActionNextItem); err != nil {
+		b.closeMenu()
+		return fmt.Errorf("set assume charset: %w", err)
+	}
+	if err := b.press(ActionSelect); err != nil {
+		return err
+	}
+	if err := b.selectMenuItem(1, okButton, ActionNextControl); err != nil {
+		b.closeMenu()
+		return fmt.Errorf("set assume charset: %w", err)
+	}
+	if err := b.press(ActionSelect); err != nil {
+		return err
+	}
+	b.s = stateIdle
+	b.menuName = ""
+	return nil
+}
+
+// Charset returns the character set of the current document as reported by
+// the Info dialog.
+func (b *Browser) Charset() (string, error) {
+	return b.CharsetContext(context.Background())
+}
+
+// CharsetContext is like Charset but bounds waits by ctx.
+func (b *Browser) CharsetContext(ctx context.Context) (string, error) {
+	info, err := b.DocumentInfoContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.Encoding, nil
+}
@@ -0,0 +1,124 @@
+// Command links2ctl drives links2 from the command line.
+//
+// Usage:
+//
+//	links2ctl fetch URL        print the source of URL
+//	links2ctl dump [-width N] URL
+//	                           print URL rendered as text
+//	links2ctl links URL        print the links of URL, one per line
+//	links2ctl save URL FILE    save URL rendered as text to FILE
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/ajzaff/links2"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage:
+	links2ctl fetch URL
+	links2ctl dump [-width N] URL
+	links2ctl links URL
+	links2ctl save URL FILE`)
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	cmd, args := os.Args[1], os.Args[2:]
+	var err error
+	switch cmd {
+	case "fetch":
+		err = fetch(ctx, args)
+	case "dump":
+		err = dump(ctx, args)
+	case "links":
+		err = links(ctx, args)
+	case "save":
+		err = save(ctx, args)
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "links2ctl %s: %v\n", cmd, err)
+		os.Exit(1)
+	}
+}
+
+func fetch(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		usage()
+	}
+	src, err := links2.FetchSource(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(src)
+	return err
+}
+
+func dump(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	width := fs.Int("width", 80, "width of the rendered text")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		usage()
+	}
+	text, err := links2.Dump(ctx, fs.Arg(0), *width)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Print(text)
+	return err
+}
+
+func links(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		usage()
+	}
+	return withBrowser(ctx, args[0], func(b *links2.Browser) error {
+		links, err := b.LinksContext(ctx)
+		if err != nil {
+			return err
+		}
+		for _, l := range links {
+			fmt.Printf("%s\t%s\n", l.URL, l.Text)
+		}
+		return nil
+	})
+}
+
+func save(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		usage()
+	}
+	return withBrowser(ctx, args[0], func(b *links2.Browser) error {
+		return b.SaveFormattedDocumentContext(ctx, args[1], true)
+	})
+}
+
+// withBrowser opens a Browser at url, calls f and quits.
+func withBrowser(ctx context.Context, url string, f func(*links2.Browser) error) error {
+	var b links2.Browser
+	if err := b.OpenContext(ctx); err != nil {
+		return err
+	}
+	if _, err := b.NavigateContext(ctx, url); err != nil {
+		b.Close()
+		return err
+	}
+	if err := f(&b); err != nil {
+		b.Close()
+		return err
+	}
+	return b.QuitContext(ctx)
+}
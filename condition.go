@@ -0,0 +1,181 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// downloadPollInterval is how often WaitUntil refreshes the download statuses
+// for DownloadComplete.
+const downloadPollInterval = 500 * time.Millisecond
+
+// escapeSequence matches the CSI sequences in patterns, for comparing them
+// with the rendered screen.
+var escapeSequence = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// A Condition is a predicate on the rendered screen and the Browser state
+// for WaitUntil. Conditions are made by TextVisible, MenuOpen, PageLoaded and
+// DownloadComplete, and combined by Any and All.
+type Condition struct {
+	name string
+	// polls is set when the condition depends on the download statuses,
+	// which aren't shown on the screen until refreshed.
+	polls bool
+	holds func(b *Browser, screen []string) bool
+}
+
+// String returns a description of c, as used in WaitUntil errors.
+func (c Condition) String() string { return c.name }
+
+// TextVisible holds when the rendered screen contains text.
+func TextVisible(text string) Condition {
+	return Condition{
+		name: fmt.Sprintf("text %q", text),
+		holds: func(_ *Browser, screen []string) bool {
+			return strings.Contains(strings.Join(screen, "\n"), text)
+		},
+	}
+}
+
+// MenuOpen holds when the menu bar of the dropdown menu is shown.
+func MenuOpen() Condition {
+	return Condition{
+		name: "menu open",
+		holds: func(b *Browser, screen []string) bool {
+			return strings.Contains(collapseSpace(screen[0]), collapseSpace(b.plain(dropdownMenu)))
+		},
+	}
+}
+
+// PageLoaded holds when a document was loaded and the status bar shows no
+// load phase.
+func PageLoaded() Condition {
+	return Condition{
+		name: "page loaded",
+		holds: func(b *Browser, screen []string) bool {
+			if b.lastURL == "" {
+				return false
+			}
+			status := strings.TrimSpace(screen[len(screen)-1])
+			for _, pattern := range phasePatterns {
+				if strings.HasPrefix(status, b.plain(pattern)) {
+					return false
+				}
+			}
+			return !strings.HasPrefix(status, b.plain(downloadReceived))
+		},
+	}
+}
+
+// DownloadComplete holds when d completed or was cancelled. While waiting,
+// the download statuses are refreshed every downloadPollInterval, which
+// opens the Downloads menu.
+func DownloadComplete(d *Download) Condition {
+	return Condition{
+		name:  fmt.Sprintf("download %s complete", d.URL),
+		polls: true,
+		holds: func(*Browser, []string) bool {
+			select {
+			case <-d.done:
+				return true
+			default:
+				return false
+			}
+		},
+	}
+}
+
+// All holds when all of conds hold. All of no conditions always holds.
+func All(conds ...Condition) Condition {
+	return combine("all", conds, func(b *Browser, screen []string) bool {
+		for _, c := range conds {
+			if !c.holds(b, screen) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Any holds when any of conds holds. Any of no conditions never holds.
+func Any(conds ...Condition) Condition {
+	return combine("any", conds, func(b *Browser, screen []string) bool {
+		for _, c := range conds {
+			if c.holds(b, screen) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func combine(op string, conds []Condition, holds func(*Browser, []string) bool) Condition {
+	names := make([]string, len(conds))
+	polls := false
+	for i, c := range conds {
+		names[i] = c.name
+		polls = polls || c.polls
+	}
+	return Condition{
+		name:  op + "(" + strings.Join(names, ", ") + ")",
+		polls: polls,
+		holds: holds,
+	}
+}
+
+// WaitUntil blocks until all of conds hold or ctx is done. Conditions are
+// checked again whenever output changed the screen. Close and Quit interrupt
+// the wait, which then returns ErrClosed. It's named WaitUntil since Wait
+// waits for links2 to exit.
+func (b *Browser) WaitUntil(ctx context.Context, conds ...Condition) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if b.c == nil {
+		return ErrNotStarted
+	}
+	c := All(conds...)
+	if len(conds) == 1 {
+		c = conds[0]
+	}
+	var poll <-chan time.Time
+	if c.polls {
+		t := time.NewTicker(downloadPollInterval)
+		defer t.Stop()
+		poll = t.C
+	}
+	for {
+		_, err, changed := b.reader.state()
+		if c.holds(b, b.scr.lines()) {
+			b.reader.consumeAll()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-changed:
+		case <-poll:
+			if _, err := b.DownloadsContext(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("wait for %s: %w", c, ctx.Err())
+		case <-b.closing.wait():
+			return fmt.Errorf("wait for %s: %w", c, ErrClosed)
+		}
+	}
+}
+
+// plain returns the translation of the English text s without its escape
+// sequences, as it appears on the rendered screen.
+func (b *Browser) plain(s string) string {
+	return escapeSequence.ReplaceAllString(b.tr(s), "")
+}
+
+// collapseSpace replaces runs of spaces in s by a single space.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,168 @@
+// Package config reads and writes the links2 configuration file links.cfg.
+//
+// Links2 stores one option per line as a name followed by its arguments,
+// separated by spaces:
+//
+//	http_proxy "proxy.example.com:3128"
+//	timeout_when_trying_multiple_addresses 3
+//
+// String arguments are double quoted with backslash escapes, numbers are
+// bare. Lines starting with # are comments.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Option is one line of the configuration file.
+type Option struct {
+	Name string
+	Args []string
+}
+
+// Config is the options of a configuration file in file order.
+type Config []Option
+
+// Get returns the arguments of the last option with the given name.
+func (c Config) Get(name string) ([]string, bool) {
+	for i := len(c) - 1; i >= 0; i-- {
+		if c[i].Name == name {
+			return c[i].Args, true
+		}
+	}
+	return nil, false
+}
+
+// Set replaces the arguments of the options with the given name, or appends
+// a new option if there is none.
+func (c *Config) Set(name string, args ...string) {
+	found := false
+	for i := range *c {
+		if (*c)[i].Name == name {
+			(*c)[i].Args = args
+			found = true
+		}
+	}
+	if !found {
+		*c = append(*c, Option{Name: name, Args: args})
+	}
+}
+
+// DefaultPath returns the path of the configuration file of the current user.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".links", "links.cfg"), nil
+}
+
+// Read parses a configuration file.
+func Read(r io.Reader) (Config, error) {
+	var c Config
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		s := strings.TrimSpace(sc.Text())
+		if s == "" || s[0] == '#' {
+			continue
+		}
+		f, err := fields(s)
+		if err != nil {
+			return nil, fmt.Errorf("config: line %d: %v", line, err)
+		}
+		c = append(c, Option{Name: f[0], Args: f[1:]})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// fields splits s into bare and quoted words.
+func fields(s string) ([]string, error) {
+	var f []string
+	for s != "" {
+		if s[0] != '"' {
+			end := strings.IndexFunc(s, unicode.IsSpace)
+			if end < 0 {
+				end = len(s)
+			}
+			f = append(f, s[:end])
+			s = strings.TrimLeftFunc(s[end:], unicode.IsSpace)
+			continue
+		}
+		var sb strings.Builder
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+			}
+			sb.WriteByte(s[i])
+		}
+		if i == len(s) {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		f = append(f, sb.String())
+		s = strings.TrimLeftFunc(s[i+1:], unicode.IsSpace)
+	}
+	return f, nil
+}
+
+// Write writes c in the configuration file format. Arguments which are not
+// integers are quoted.
+func Write(w io.Writer, c Config) error {
+	bw := bufio.NewWriter(w)
+	for _, o := range c {
+		if o.Name == "" || strings.ContainsAny(o.Name, " \t\r\n\"") {
+			return fmt.Errorf("config: invalid option name: %q", o.Name)
+		}
+		bw.WriteString(o.Name)
+		for _, arg := range o.Args {
+			if strings.ContainsAny(arg, "\r\n") {
+				return fmt.Errorf("config: option %s: argument contains a newline", o.Name)
+			}
+			bw.WriteByte(' ')
+			bw.WriteString(quote(arg))
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func quote(arg string) string {
+	if _, err := strconv.Atoi(arg); err == nil {
+		return arg
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(arg) + `"`
+}
+
+// Load reads the configuration file at path.
+func Load(path string) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Save writes c to the file at path, replacing it.
+func Save(path string, c Config) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, c); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Config
+	}{
+		{"timeout 3\n", Config{{"timeout", []string{"3"}}}},
+		{"a\t1\t 2\n", Config{{"a", []string{"1", "2"}}}},
+		{"a  1   2  \n", Config{{"a", []string{"1", "2"}}}},
+		{`proxy "host:3128"` + "\n", Config{{"proxy", []string{"host:3128"}}}},
+		{`a "two words" "" 1`, Config{{"a", []string{"two words", "", "1"}}}},
+		{`a "say \"hi\"" "back\\slash"`, Config{{"a", []string{`say "hi"`, `back\slash`}}}},
+		{`a "x"	"y"`, Config{{"a", []string{"x", "y"}}}},
+		{"# comment\n\n  b 1\n", Config{{"b", []string{"1"}}}},
+		{"flag\n", Config{{"flag", []string{}}}},
+	}
+	for _, tc := range tests {
+		c, err := Read(strings.NewReader(tc.in))
+		if err != nil {
+			t.Errorf("Read(%q): %v", tc.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(c, tc.want) {
+			t.Errorf("Read(%q) = %q, want %q", tc.in, c, tc.want)
+		}
+	}
+}
+
+func TestReadError(t *testing.T) {
+	for _, in := range []string{`a "open`, `a "escaped\"`} {
+		if _, err := Read(strings.NewReader(in)); err == nil {
+			t.Errorf("Read(%q) succeeded", in)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	c := Config{
+		{"timeout", []string{"3"}},
+		{"proxy", []string{"host:3128"}},
+		{"a", []string{`say "hi"`, `back\slash`, "", "tab\there"}},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, c); err != nil {
+		t.Fatal(err)
+	}
+	want := "timeout 3\nproxy \"host:3128\"\na \"say \\\"hi\\\"\" \"back\\\\slash\" \"\" \"tab\there\"\n"
+	if buf.String() != want {
+		t.Errorf("Write = %q, want %q", buf.String(), want)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("round trip = %q, want %q", got, c)
+	}
+}
+
+func TestWriteInvalid(t *testing.T) {
+	for _, c := range []Config{
+		{{"", nil}},
+		{{"two words", nil}},
+		{{"a", []string{"line\nbreak"}}},
+	} {
+		if err := Write(&bytes.Buffer{}, c); err == nil {
+			t.Errorf("Write(%q) succeeded", c)
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.cfg")
+	var c Config
+	c.Set("a", "1")
+	c.Set("b", "x y")
+	c.Set("a", "2")
+	if err := Save(path, c); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args, _ := got.Get("a"); !reflect.DeepEqual(args, []string{"2"}) {
+		t.Errorf("Get(a) = %q", args)
+	}
+	if args, _ := got.Get("b"); !reflect.DeepEqual(args, []string{"x y"}) {
+		t.Errorf("Get(b) = %q", args)
+	}
+}
@@ -0,0 +1,108 @@
+package links2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ajzaff/links2/config"
+	"github.com/ajzaff/links2/cookies"
+	"github.com/ajzaff/links2/history"
+)
+
+// WithConfig runs links2 with a fresh home directory whose links.cfg holds
+// cfg, so the settings don't depend on the user's ~/.links. The directory is
+// removed by Close. See the config subpackage for the file format.
+func WithConfig(cfg config.Config) Option {
+	return func(o *options) error {
+		o.config = cfg
+		return nil
+	}
+}
+
+// WithDownloadDir runs links2 in dir, so downloads and saved documents land
+// there, and resolves relative paths given to SaveFormattedDocument and
+// DownloadLink against it.
+func WithDownloadDir(dir string) Option {
+	return func(o *options) error {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		fi, err := os.Stat(abs)
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			return fmt.Errorf("download dir is not a directory: %q", dir)
+		}
+		o.downloadDir = abs
+		return nil
+	}
+}
+
+// resolvePath resolves a relative path against the directory of
+// WithDownloadDir, if any.
+func (b *Browser) resolvePath(path string) string {
+	if b.opts == nil || b.opts.downloadDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(b.opts.downloadDir, path)
+}
+
+// WithGotoHistory runs links2 with a fresh home directory, as WithConfig
+// does, whose Go to URL dialog history holds urls, oldest first. See the
+// history subpackage for the file format.
+func WithGotoHistory(urls ...string) Option {
+	return func(o *options) error {
+		for _, u := range urls {
+			if err := checkInput(u); err != nil {
+				return err
+			}
+		}
+		if o.config == nil {
+			o.config = config.Config{}
+		}
+		o.gotoHistory = urls
+		return nil
+	}
+}
+
+// withConfigOption sets a links.cfg option on top of the config of
+// WithConfig, running links2 with a fresh home as WithConfig does.
+func withConfigOption(name string, args ...string) Option {
+	return func(o *options) error {
+		o.configure = append(o.configure, func(c *config.Config) { c.Set(name, args...) })
+		return nil
+	}
+}
+
+// makeHome creates a temporary home directory holding the links.cfg of o.
+func makeHome(o *options) (string, error) {
+	home, err := os.MkdirTemp("", "links2-")
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".links")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		os.RemoveAll(home)
+		return "", err
+	}
+	if err := config.Save(filepath.Join(dir, "links.cfg"), o.config); err != nil {
+		os.RemoveAll(home)
+		return "", err
+	}
+	if o.gotoHistory != nil {
+		if err := history.SaveGoto(filepath.Join(dir, history.GotoFile), o.gotoHistory); err != nil {
+			os.RemoveAll(home)
+			return "", err
+		}
+	}
+	if o.cookies != nil {
+		if err := cookies.Save(filepath.Join(dir, "cookies"), o.cookies); err != nil {
+			os.RemoveAll(home)
+			return "", err
+		}
+	}
+	return home, nil
+}
@@ -0,0 +1,57 @@
+package links2
+
+import (
+	"io"
+	"os"
+
+	"github.com/Netflix/go-expect"
+)
+
+// Console is the terminal controller a Browser drives links2 through.
+// It's implemented by *expect.Console.
+type Console interface {
+	Send(s string) (int, error)
+	Expect(opts ...expect.ExpectOpt) (string, error)
+	// Tty returns the terminal links2 runs on. If it's nil, no links2
+	// process is started and the Console plays its part, e.g. as a mock.
+	Tty() *os.File
+	Close() error
+}
+
+// WithConsole uses the Console returned by newConsole instead of a go-expect
+// console. The Console must copy all output it reads to stdout, which keeps
+// the screen model and WithTee up to date. Expect is called without matchers
+// from a single goroutine reading the output, concurrently with Send. Keys
+// sent are not logged or recorded by macros unless the Console does so
+// itself.
+func WithConsole(newConsole func(stdout io.Writer) (Console, error)) Option {
+	return func(o *options) error {
+		o.console = newConsole
+		return nil
+	}
+}
+
+// newConsole creates the Console of o copying output to out.
+func (b *Browser) newConsole(o *options, out io.Writer) (Console, error) {
+	if o.console != nil {
+		return o.console(consoleOut(o, out))
+	}
+	opts := append(append(consoleLogOpts(o.logger), teeOpts(o)...),
+		expect.WithStdout(out),
+		expect.WithSendObserver(b.record),
+		expect.WithSendObserver(b.castInput),
+	)
+	opts = append(opts, o.consoleOpts...)
+	if o.pty != nil {
+		return newPTYConsole(o.pty, opts...)
+	}
+	return expect.NewConsole(opts...)
+}
+
+// consoleOut returns the writer a Console of o copies output to.
+func consoleOut(o *options, out io.Writer) io.Writer {
+	if o.teeOut != nil {
+		return io.MultiWriter(out, o.teeOut)
+	}
+	return out
+}
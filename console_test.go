@@ -0,0 +1,103 @@
+package links2
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Netflix/go-expect"
+)
+
+// fakeConsole plays a minimal links2 for tests: it shows the welcome screen,
+// which the first Esc dismisses, and then opens and closes the dropdown menu
+// on each Esc. Other keys are recorded and ignored.
+type fakeConsole struct {
+	stdout io.Writer
+	out    chan string
+	done   chan struct{}
+
+	mu      sync.Mutex
+	welcome bool
+	menu    bool
+	sent    []string
+	onSend  func(keys string)
+	closing sync.Once
+}
+
+func newFakeConsole(stdout io.Writer) *fakeConsole {
+	c := &fakeConsole{stdout: stdout, out: make(chan string, 16), done: make(chan struct{}), welcome: true}
+	c.out <- "\033[H\033[2JWelcome to links!"
+	return c
+}
+
+func (c *fakeConsole) Send(s string) (int, error) {
+	c.mu.Lock()
+	c.sent = append(c.sent, s)
+	onSend := c.onSend
+	var redraw string
+	if s == "\033" { // Esc
+		switch {
+		case c.welcome:
+			c.welcome = false
+			redraw = "\033[H\033[2J"
+		case c.menu:
+			c.menu = false
+			redraw = "\033[H\033[K"
+		default:
+			c.menu = true
+			redraw = "\033[H" + dropdownMenu
+		}
+	}
+	c.mu.Unlock()
+	if onSend != nil {
+		onSend(s)
+	}
+	if redraw != "" {
+		select {
+		case c.out <- redraw:
+		case <-c.done:
+			return 0, os.ErrClosed
+		}
+	}
+	return len(s), nil
+}
+
+func (c *fakeConsole) Expect(opts ...expect.ExpectOpt) (string, error) {
+	var eo expect.ExpectOpts
+	for _, opt := range opts {
+		if err := opt(&eo); err != nil {
+			return "", err
+		}
+	}
+	var timeout <-chan time.Time
+	if eo.ReadTimeout != nil {
+		timer := time.NewTimer(*eo.ReadTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case s := <-c.out:
+		_, err := io.WriteString(c.stdout, s)
+		return s, err
+	case <-timeout:
+		return "", os.ErrDeadlineExceeded
+	case <-c.done:
+		return "", io.EOF
+	}
+}
+
+func (c *fakeConsole) Tty() *os.File { return nil }
+
+func (c *fakeConsole) Close() error {
+	c.closing.Do(func() { close(c.done) })
+	return nil
+}
+
+// withFakeConsole runs the Browser on a fakeConsole, stored in *c.
+func withFakeConsole(c **fakeConsole) Option {
+	return WithConsole(func(stdout io.Writer) (Console, error) {
+		*c = newFakeConsole(stdout)
+		return *c, nil
+	})
+}
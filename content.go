@@ -0,0 +1,157 @@
+package links2
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ajzaff/links2/config"
+)
+
+const (
+	unknownTypeDialog = "Unknown type \033[0;7m"
+	whatToDoDialog    = "What to do? \033[0;7m"
+	cancelButton      = "[ Cancel ]"
+	saveButton        = "[ Save ]"
+	displayButton     = "[ Display ]"
+	openButton        = "[ Open ]"
+)
+
+// ErrContentBlocked is returned by Navigate when a document links2 can't
+// display is blocked rather than saved, displayed or opened.
+var ErrContentBlocked = errors.New("content blocked")
+
+// ContentAction is what to do with a document links2 can't display.
+type ContentAction int
+
+const (
+	ContentBlock   ContentAction = iota // ContentBlock cancels the load.
+	ContentSave                         // ContentSave downloads the document, see NavigateResult.Download.
+	ContentDisplay                      // ContentDisplay displays the document as text.
+	ContentOpen                         // ContentOpen opens the document with its Association.
+)
+
+// ContentPolicy decides what to do with the document at u of a content type
+// links2 can't display, e.g. "application/pdf".
+type ContentPolicy func(contentType string, u *url.URL) ContentAction
+
+// WithContentPolicy answers the dialog links2 shows for documents it can't
+// display with p, instead of the load stalling on it. By default such
+// documents are blocked and Navigate returns ErrContentBlocked.
+func WithContentPolicy(p ContentPolicy) Option {
+	return func(o *options) error {
+		o.contentPolicy = p
+		return nil
+	}
+}
+
+// Association runs an external program for documents of a content type,
+// like the associations of Setup→Associations.
+type Association struct {
+	Label       string // Label is the name shown in the What to do? dialog.
+	ContentType string // ContentType is the MIME type, e.g. "application/pdf".
+	// Program is the shell command run, where % is replaced by the name of
+	// a file holding the document.
+	Program string
+	Ask     bool // Ask shows the What to do? dialog before running Program.
+}
+
+// WithAssociations adds associations to the links.cfg of WithConfig.
+func WithAssociations(as ...Association) Option {
+	return func(o *options) error {
+		for _, a := range as {
+			if a.ContentType == "" || a.Program == "" {
+				return fmt.Errorf("association needs a content type and program: %+v", a)
+			}
+			a := a
+			o.configure = append(o.configure, func(c *config.Config) {
+				*c = append(*c, config.Option{Name: "association", Args: a.args()})
+			})
+		}
+		return nil
+	}
+}
+
+// args returns the links.cfg arguments of a: its label, content type and
+// program, flags and system. The flags are the sum of 1 to block the
+// terminal, 2 for a console program, 4 for an X program and 8 to ask.
+func (a Association) args() []string {
+	label := a.Label
+	if label == "" {
+		label = a.ContentType
+	}
+	flags := 1 | 2 // Console programs block the terminal.
+	if a.Ask {
+		flags |= 8
+	}
+	return []string{label, a.ContentType, a.Program, strconv.Itoa(flags), "0"}
+}
+
+// contentTypePattern matches a MIME type in a dialog.
+var contentTypePattern = regexp.MustCompile(`[a-z]+/[-+.\w]+`)
+
+// answerContent answers the Unknown type or What to do? dialog.
+func (b *Browser) answerContent(res *NavigateResult) error {
+	body, err := b.expectString(cancelButton)
+	if err != nil {
+		return err
+	}
+	ct := strings.TrimRight(contentTypePattern.FindString(strings.Join(dialogLines(body), " ")), ".")
+	action := ContentBlock
+	switch {
+	case b.saveAs != "":
+		action = ContentSave
+	case b.opts.contentPolicy != nil:
+		action = b.opts.contentPolicy(ct, res.URL)
+	}
+	if action == ContentSave && b.anonymous() {
+		b.c.Send("\033") // Esc cancels.
+		return b.checkAnonymous("save " + ct)
+	}
+	button := ""
+	switch action {
+	case ContentSave:
+		button = saveButton
+	case ContentDisplay:
+		button = displayButton
+	case ContentOpen:
+		if strings.Contains(body, b.tr(openButton)) {
+			button = openButton
+		}
+	}
+	if button == "" {
+		b.c.Send("\033") // Esc cancels.
+		return fmt.Errorf("%w: %s", ErrContentBlocked, ct)
+	}
+	if err := b.selectMenuItem(1, b.tr(button), ActionNextControl); err != nil {
+		b.c.Send("\033") // Esc
+		return fmt.Errorf("%s: %w", button, err)
+	}
+	if err := b.press(ActionSelect); err != nil {
+		return err
+	}
+	if action == ContentSave {
+		name := "download"
+		if res.URL != nil {
+			if base := path.Base(res.URL.Path); base != "/" && base != "." {
+				name = sanitizeInput(base)
+			}
+		}
+		name = b.resolvePath(name)
+		if b.saveAs != "" {
+			name = b.saveAs
+		}
+		d, err := b.startDownload(name, res.urlString())
+		if err != nil {
+			return err
+		}
+		res.Download = d
+	}
+	// Esc afterwards signals the load finished as in Navigate.
+	b.c.Send("\033")
+	return nil
+}
@@ -0,0 +1,119 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/ajzaff/links2/config"
+	"github.com/ajzaff/links2/cookies"
+)
+
+// SetCookies replaces the cookie store of the next Open with the given
+// cookies. Links2 loads cookies when it starts, so SetCookies must be called
+// before Open. See the cookies subpackage for the file format.
+//
+// The cookies are written to the home links2 will use: the profile of
+// WithProfile, or else a fresh home, as with WithConfig, whose links.cfg is
+// a copy of the user's unless WithConfig is given. The user's own
+// ~/.links/cookies is never touched.
+func (b *Browser) SetCookies(cs []*http.Cookie) error {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.s != stateUndefined {
+		return fmt.Errorf("set cookies: %w", ErrAlreadyStarted)
+	}
+	if err := cookies.Write(io.Discard, cs); err != nil {
+		return fmt.Errorf("set cookies: %w", err)
+	}
+	b.setCookies = cs
+	return nil
+}
+
+// applyCookies hands the cookies of SetCookies to o, which then seeds the
+// profile of o or a fresh home with them.
+func (b *Browser) applyCookies(o *options) error {
+	if b.setCookies == nil {
+		return nil
+	}
+	o.cookies = b.setCookies
+	if o.config == nil && o.profile == nil {
+		cfg, err := userConfig()
+		if err != nil {
+			return err
+		}
+		o.config = cfg
+	}
+	return nil
+}
+
+// userConfig returns the user's links.cfg, or an empty config if there is
+// none.
+func userConfig() (config.Config, error) {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config.Config{}, nil
+	}
+	return cfg, err
+}
+
+// cookieStore is the cookie store of the last links2 process, which
+// outlives Close.
+type cookieStore struct {
+	// path is the cookies file, unless it was in a fresh home.
+	path string
+	// snapshot is the cookies file of a fresh home, read before the home
+	// was removed, and err why it couldn't be read.
+	snapshot []byte
+	err      error
+}
+
+// snapshotCookies keeps the cookies file of the fresh home about to be
+// removed, as links2 left it on exit.
+func (b *Browser) snapshotCookies() {
+	if b.home == "" {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(b.home, ".links", "cookies"))
+	b.proc.cookies = cookieStore{snapshot: data, err: err}
+}
+
+// Cookies returns the cookies in the cookie store of the Browser: that of
+// the profile of WithProfile, that of the fresh home of WithConfig or
+// SetCookies, or the user's, whichever links2 last used, or the user's if
+// the Browser was never opened.
+//
+// Links2 saves cookies when it exits, so the cookies reflect the store as of
+// the last exit, e.g. after a call to Quit. A fresh home is removed by Close,
+// but its cookies are kept for Cookies. If links2 left none there, the
+// error wraps fs.ErrNotExist.
+func (b *Browser) Cookies() ([]*http.Cookie, error) {
+	_, done := b.begin(context.Background())
+	home, store := b.home, b.proc.cookies
+	done()
+	switch {
+	case home != "":
+		return cookies.Load(filepath.Join(home, ".links", "cookies"))
+	case store.path != "":
+		return cookies.Load(store.path)
+	case store.err != nil:
+		return nil, fmt.Errorf("cookies: %w", store.err)
+	case store.snapshot != nil:
+		return cookies.Read(bytes.NewReader(store.snapshot))
+	}
+	path, err := cookies.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return cookies.Load(path)
+}
@@ -0,0 +1,37 @@
+package links2
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestCookiesAfterClose checks that the cookies of a fresh home are still
+// returned once Close removed it.
+func TestCookiesAfterClose(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // No user links.cfg to copy.
+	var (
+		fake *fakeConsole
+		b    Browser
+	)
+	in := []*http.Cookie{{Name: "sid", Value: "abc", Domain: "example.com", Path: "/", Expires: time.Unix(1700000000, 0)}}
+	if err := b.SetCookies(in); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Open(withFakeConsole(&fake)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetCookies(in); err == nil {
+		t.Error("SetCookies while open: no error")
+	}
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := b.Cookies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0].Name != "sid" || out[0].Value != "abc" {
+		t.Errorf("Cookies() = %v, want %v", out, in)
+	}
+}
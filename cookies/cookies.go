@@ -0,0 +1,128 @@
+// Package cookies reads and writes the links2 cookies file.
+//
+// Links2 stores one cookie per line as space separated fields:
+//
+//	name value server path domain expires secure
+//
+// where server is the host which set the cookie, expires is a Unix time and
+// secure is 0 or 1. Only persistent cookies are saved.
+package cookies
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPath returns the path of the cookies file of the current user.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".links", "cookies"), nil
+}
+
+// Read parses a cookies file.
+func Read(r io.Reader) ([]*http.Cookie, error) {
+	var cookies []*http.Cookie
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		s := strings.TrimSpace(sc.Text())
+		if s == "" {
+			continue
+		}
+		f := strings.Fields(s)
+		if len(f) != 7 {
+			return nil, fmt.Errorf("cookies: line %d: want 7 fields, got %d", line, len(f))
+		}
+		expires, err := strconv.ParseInt(f[5], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cookies: line %d: invalid expires: %q", line, f[5])
+		}
+		domain := f[4]
+		if domain == "" || domain == "-" {
+			domain = f[2]
+		}
+		cookies = append(cookies, &http.Cookie{
+			Name:    f[0],
+			Value:   f[1],
+			Path:    f[3],
+			Domain:  domain,
+			Expires: time.Unix(expires, 0),
+			Secure:  f[6] == "1",
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return cookies, nil
+}
+
+// Write writes cookies in the links2 cookies file format.
+//
+// Cookies must have a Domain since it's used as the server which set the
+// cookie. Cookies without an expiry are saved to expire in a year, since
+// links2 only loads persistent cookies.
+func Write(w io.Writer, cookies []*http.Cookie) error {
+	bw := bufio.NewWriter(w)
+	for _, c := range cookies {
+		if c.Name == "" || c.Value == "" {
+			return fmt.Errorf("cookies: cookie %q has no name or value", c.Name)
+		}
+		if c.Domain == "" {
+			return fmt.Errorf("cookies: cookie %q has no domain", c.Name)
+		}
+		if strings.ContainsAny(c.Name+c.Value+c.Path+c.Domain, " \t\r\n") {
+			return fmt.Errorf("cookies: cookie %q contains whitespace", c.Name)
+		}
+		expires := c.Expires
+		if expires.IsZero() {
+			expires = time.Now().AddDate(1, 0, 0)
+		}
+		path := c.Path
+		if path == "" {
+			path = "/"
+		}
+		secure := 0
+		if c.Secure {
+			secure = 1
+		}
+		server := strings.TrimPrefix(c.Domain, ".")
+		fmt.Fprintf(bw, "%s %s %s %s %s %d %d\n", c.Name, c.Value, server, path, c.Domain, expires.Unix(), secure)
+	}
+	return bw.Flush()
+}
+
+// Load reads the cookies file at path.
+func Load(path string) ([]*http.Cookie, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Save writes cookies to the file at path, replacing it. The directory of
+// path is created if needed.
+func Save(path string, cookies []*http.Cookie) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, cookies); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,104 @@
+package cookies
+
+import (
+	"bytes"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRead(t *testing.T) {
+	in := "sid abc example.com / .example.com 1700000000 1\n\n" +
+		"pref dark host.org /app - 1800000000 0\n"
+	cs, err := Read(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []http.Cookie{
+		{Name: "sid", Value: "abc", Path: "/", Domain: ".example.com", Expires: time.Unix(1700000000, 0), Secure: true},
+		{Name: "pref", Value: "dark", Path: "/app", Domain: "host.org", Expires: time.Unix(1800000000, 0)},
+	}
+	if len(cs) != len(want) {
+		t.Fatalf("read %d cookies, want %d", len(cs), len(want))
+	}
+	for i, c := range cs {
+		w := want[i]
+		if c.Name != w.Name || c.Value != w.Value || c.Path != w.Path || c.Domain != w.Domain ||
+			!c.Expires.Equal(w.Expires) || c.Secure != w.Secure {
+			t.Errorf("cookie %d = %+v, want %+v", i, *c, w)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, in := range []string{
+		"sid example.com / .example.com 1700000000 1\n", // no value
+		"sid abc example.com / .example.com soon 1\n",
+		"a b c d e f g h\n",
+	} {
+		if _, err := Read(strings.NewReader(in)); err == nil {
+			t.Errorf("Read(%q): no error", in)
+		}
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	for _, c := range []*http.Cookie{
+		{Name: "sid", Value: "", Domain: "example.com"},
+		{Name: "", Value: "abc", Domain: "example.com"},
+		{Name: "sid", Value: "abc"},
+		{Name: "sid", Value: "a b", Domain: "example.com"},
+		{Name: "sid", Value: "abc\n", Domain: "example.com"},
+	} {
+		if err := Write(new(bytes.Buffer), []*http.Cookie{c}); err == nil {
+			t.Errorf("Write(%+v): no error", *c)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	expires := time.Unix(1700000000, 0)
+	in := []*http.Cookie{
+		{Name: "sid", Value: "abc", Domain: ".example.com", Path: "/", Expires: expires, Secure: true},
+		{Name: "pref", Value: "x=1", Domain: "host.org", Path: "/app", Expires: expires},
+		{Name: "nopath", Value: "v", Domain: "host.org", Expires: expires},
+	}
+	path := filepath.Join(t.TempDir(), "dir", "cookies") // Save creates dir.
+	if err := Save(path, in); err != nil {
+		t.Fatal(err)
+	}
+	out, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != len(in) {
+		t.Fatalf("loaded %d cookies, want %d", len(out), len(in))
+	}
+	for i, c := range out {
+		w := in[i]
+		wantPath := w.Path
+		if wantPath == "" {
+			wantPath = "/"
+		}
+		if c.Name != w.Name || c.Value != w.Value || c.Domain != w.Domain || c.Path != wantPath ||
+			!c.Expires.Equal(w.Expires) || c.Secure != w.Secure {
+			t.Errorf("cookie %d = %+v, want %+v", i, *c, *w)
+		}
+	}
+}
+
+func TestWriteDefaultExpiry(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, []*http.Cookie{{Name: "s", Value: "v", Domain: "h"}}); err != nil {
+		t.Fatal(err)
+	}
+	cs, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cs[0].Expires.After(time.Now().AddDate(0, 11, 0)) {
+		t.Errorf("expires %v, want about a year from now", cs[0].Expires)
+	}
+}
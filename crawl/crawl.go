@@ -0,0 +1,202 @@
+// Package crawl crawls the web with links2 Browsers.
+//
+// Pages are visited breadth first from a set of seed URLs. The text and
+// links of each page are reported on a channel, and links are followed up to
+// a maximum depth. Politeness delays are configured on the Browsers, e.g.
+// with links2.WithHostDelay.
+package crawl
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+
+	"github.com/ajzaff/links2"
+)
+
+// Config configures a crawl.
+type Config struct {
+	Seeds []string // Seeds are the URLs crawled at depth 0.
+	// Depth is the maximum number of links followed from a seed.
+	Depth int
+	// Filter reports whether to follow a link. Only http and https links are
+	// followed, and only those for which Filter returns true if it's set.
+	Filter func(u *url.URL) bool
+	// Workers is the number of pages visited at once, 1 if unset. It should
+	// not exceed the size of the Pool.
+	Workers int
+}
+
+// Result is a visited page.
+type Result struct {
+	URL   string
+	Depth int
+	Text  string        // Text is the formatted text of the page.
+	Links []links2.Link // Links are the links of the page in document order.
+	Err   error         // Err is set if the page could not be visited.
+}
+
+// Hosts returns a Filter which follows links to the given hosts only.
+func Hosts(hosts ...string) func(u *url.URL) bool {
+	set := make(map[string]bool, len(hosts))
+	for _, h := range hosts {
+		set[h] = true
+	}
+	return func(u *url.URL) bool { return set[u.Hostname()] }
+}
+
+// Crawl crawls from the seeds of c with Browsers from pool, sending a Result
+// for each page visited. The channel is closed when the crawl is finished or
+// ctx is done. Each URL is visited once, ignoring fragments.
+func Crawl(ctx context.Context, pool *links2.Pool, c Config) <-chan Result {
+	out := make(chan Result)
+	go func() {
+		defer close(out)
+		crawl(ctx, pool, c, out)
+	}()
+	return out
+}
+
+// task is a page to visit.
+type task struct {
+	url   string
+	depth int
+}
+
+func crawl(ctx context.Context, pool *links2.Pool, c Config, out chan<- Result) {
+	var (
+		tasks = make(chan task)
+		found = make(chan []task) // found are the links of each visited page.
+		wg    sync.WaitGroup
+	)
+	workers := max(c.Workers, 1)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				res, next := visit(ctx, pool, c, t)
+				select {
+				case out <- res:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case found <- next:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	defer func() {
+		close(tasks)
+		wg.Wait()
+	}()
+
+	var (
+		queue   []task
+		seen    = make(map[string]bool)
+		pending int // pending is the number of tasks being visited.
+	)
+	add := func(ts []task) {
+		for _, t := range ts {
+			if !seen[t.url] {
+				seen[t.url] = true
+				queue = append(queue, t)
+			}
+		}
+	}
+	for _, s := range c.Seeds {
+		u, err := url.Parse(s)
+		if err != nil {
+			select {
+			case out <- Result{URL: s, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		u.Fragment, u.RawFragment = "", ""
+		add([]task{{url: u.String()}})
+	}
+	for len(queue) > 0 || pending > 0 {
+		var (
+			send chan<- task
+			next task
+		)
+		if len(queue) > 0 {
+			send, next = tasks, queue[0]
+		}
+		select {
+		case send <- next:
+			queue = queue[1:]
+			pending++
+		case ts := <-found:
+			pending--
+			add(ts)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// visit visits the page of t, returning its Result and the links to follow.
+func visit(ctx context.Context, pool *links2.Pool, c Config, t task) (Result, []task) {
+	res := Result{URL: t.url, Depth: t.depth}
+	b, err := pool.Acquire(ctx)
+	if err != nil {
+		res.Err = err
+		return res, nil
+	}
+	res.Err = page(ctx, b, &res)
+	pool.Release(b, broken(res.Err))
+	if res.Err != nil || t.depth >= c.Depth {
+		return res, nil
+	}
+	base, err := url.Parse(t.url)
+	if err != nil {
+		return res, nil
+	}
+	var next []task
+	for _, l := range res.Links {
+		u, err := base.Parse(l.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		u.Fragment, u.RawFragment = "", ""
+		if c.Filter != nil && !c.Filter(u) {
+			continue
+		}
+		next = append(next, task{url: u.String(), depth: t.depth + 1})
+	}
+	return res, next
+}
+
+// page navigates b to res.URL and reads its text and links into res.
+func page(ctx context.Context, b *links2.Browser, res *Result) error {
+	if _, err := b.NavigateContext(ctx, res.URL); err != nil {
+		return err
+	}
+	var err error
+	if res.Text, err = b.PageTextContext(ctx); err != nil {
+		return err
+	}
+	res.Links, err = b.LinksContext(ctx)
+	return err
+}
+
+// broken returns err if it leaves the Browser unusable, so the Pool replaces
+// it, and nil for page errors.
+func broken(err error) error {
+	switch {
+	case errors.Is(err, links2.ErrProcessExited),
+		errors.Is(err, links2.ErrTimeout),
+		errors.Is(err, links2.ErrMenuOpen),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package crawl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/ajzaff/links2"
+)
+
+// Graph is a graph of visited pages and their links. The zero Graph is
+// empty and ready to use.
+type Graph struct {
+	pages map[string]bool            // pages are the visited URLs.
+	edges map[string]map[string]bool // edges maps page URLs to link URLs.
+}
+
+// Add adds the page and links of a Result, as sent by Crawl. Results with
+// an error add only the page.
+func (g *Graph) Add(r Result) {
+	if r.Err != nil {
+		g.AddPage(r.URL, nil)
+		return
+	}
+	g.AddPage(r.URL, r.Links)
+}
+
+// AddPage adds a visited page and its links, e.g. as returned by
+// Browser.Links after Browser.Navigate. Relative links are resolved against
+// pageURL and fragments are dropped.
+func (g *Graph) AddPage(pageURL string, links []links2.Link) {
+	if g.pages == nil {
+		g.pages = make(map[string]bool)
+		g.edges = make(map[string]map[string]bool)
+	}
+	g.pages[pageURL] = true
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return
+	}
+	for _, l := range links {
+		u, err := base.Parse(l.URL)
+		if err != nil {
+			continue
+		}
+		u.Fragment, u.RawFragment = "", ""
+		if g.edges[pageURL] == nil {
+			g.edges[pageURL] = make(map[string]bool)
+		}
+		g.edges[pageURL][u.String()] = true
+	}
+}
+
+// graphNode is a node of the JSON form of a Graph.
+type graphNode struct {
+	URL     string `json:"url"`
+	Visited bool   `json:"visited"`
+}
+
+// graphEdge is an edge of the JSON form of a Graph.
+type graphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// nodes returns the graph nodes sorted by URL, including link targets which
+// were not visited.
+func (g *Graph) nodes() []graphNode {
+	set := make(map[string]bool, len(g.pages))
+	for u := range g.pages {
+		set[u] = true
+	}
+	for _, to := range g.edges {
+		for u := range to {
+			if _, ok := set[u]; !ok {
+				set[u] = false
+			}
+		}
+	}
+	nodes := make([]graphNode, 0, len(set))
+	for u, visited := range set {
+		nodes = append(nodes, graphNode{URL: u, Visited: visited})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].URL < nodes[j].URL })
+	return nodes
+}
+
+// sortedEdges returns the graph edges sorted by source and target URL.
+func (g *Graph) sortedEdges() []graphEdge {
+	var edges []graphEdge
+	for from, to := range g.edges {
+		for u := range to {
+			edges = append(edges, graphEdge{From: from, To: u})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+// WriteJSON writes the graph as a JSON object with "nodes" and "edges"
+// arrays. Nodes have a "url" and whether they were "visited"; edges have a
+// "from" and "to" URL.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Nodes []graphNode `json:"nodes"`
+		Edges []graphEdge `json:"edges"`
+	}{g.nodes(), g.sortedEdges()})
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Visited pages are
+// drawn as boxes and other link targets as ellipses.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph links {"); err != nil {
+		return err
+	}
+	for _, n := range g.nodes() {
+		shape := "ellipse"
+		if n.Visited {
+			shape = "box"
+		}
+		if _, err := fmt.Fprintf(w, "\t%s [shape=%s];\n", strconv.Quote(n.URL), shape); err != nil {
+			return err
+		}
+	}
+	for _, e := range g.sortedEdges() {
+		if _, err := fmt.Fprintf(w, "\t%s -> %s;\n", strconv.Quote(e.From), strconv.Quote(e.To)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
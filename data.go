@@ -0,0 +1,45 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// NavigateHTML renders the HTML document src, e.g. a snippet in a test.
+// Relative links in src resolve against a temporary directory.
+//
+// The document is written to a file in a temporary directory which is
+// removed by Close, since long data: URLs don't fit in the Go to URL
+// dialog. Short documents can also be loaded as data: URLs with Navigate.
+func (b *Browser) NavigateHTML(src string) (NavigateResult, error) {
+	return b.NavigateHTMLContext(context.Background(), src)
+}
+
+// NavigateHTMLContext is like NavigateHTML but bounds waits by ctx.
+func (b *Browser) NavigateHTMLContext(ctx context.Context, src string) (NavigateResult, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if b.s == stateUndefined {
+		return NavigateResult{}, ErrNotStarted
+	}
+	if err := b.checkAnonymous("navigate html"); err != nil {
+		return NavigateResult{}, err
+	}
+	if b.scratch == "" {
+		dir, err := os.MkdirTemp("", "links2-html-")
+		if err != nil {
+			return NavigateResult{}, err
+		}
+		b.scratch = dir
+	}
+	b.htmlDocs++
+	path := filepath.Join(b.scratch, fmt.Sprintf("document%d.html", b.htmlDocs))
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		return NavigateResult{}, err
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return b.NavigateContext(ctx, u.String())
+}
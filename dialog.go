@@ -0,0 +1,113 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// control is a checkbox or radio button of a dialog.
+type control struct {
+	label   string
+	radio   bool
+	checked bool
+}
+
+// controlPattern matches a checkbox "[X] label" or radio button "(X) label".
+var controlPattern = regexp.MustCompile(`([\[(])([ X*])[\])] ([^\[(|]*)`)
+
+// parseControls returns the checkboxes and radio buttons drawn by lines in
+// reading order, which is also their focus order.
+func parseControls(lines []string) []control {
+	var controls []control
+	for _, line := range lines {
+		for _, m := range controlPattern.FindAllStringSubmatch(line, -1) {
+			controls = append(controls, control{
+				label:   strings.TrimSpace(m[3]),
+				radio:   m[1] == "(",
+				checked: m[2] != " ",
+			})
+		}
+	}
+	return controls
+}
+
+// openDialog walks the menu path to a dialog and waits for it to be drawn.
+func (b *Browser) openDialog(ctx context.Context, path ...string) error {
+	if err := b.OpenMenuContext(ctx, path...); err != nil {
+		return err
+	}
+	if _, err := b.expectString(okButton); err != nil {
+		b.closeMenu()
+		return err
+	}
+	_, err := b.drain()
+	return err
+}
+
+// setControls sets the checkboxes and radio buttons of the open dialog with
+// the given labels, replaces the text fields following them with fields, and
+// then accepts the dialog. A radio button can only be set and an empty field
+// is left unchanged. Labels are matched against the start of the control label,
+// and the first control matching a label is set.
+//
+// The dialog is assumed to start with its controls, focused in order with
+// Tab, followed by its text fields.
+func (b *Browser) setControls(want map[string]bool, fields ...string) error {
+	for _, f := range fields {
+		if err := checkInput(f); err != nil {
+			return err
+		}
+	}
+	translated := make(map[string]bool, len(want))
+	for label, on := range want {
+		translated[b.tr(label)] = on
+	}
+	want = translated
+	controls := parseControls(b.scr.lines())
+	for label := range want {
+		if !hasControl(controls, label) {
+			b.closeMenu()
+			return fmt.Errorf("dialog has no option %q", label)
+		}
+	}
+	var keys strings.Builder
+	pos := 0
+	matched := make(map[string]bool, len(want))
+	for i, c := range controls {
+		for label, on := range want {
+			if matched[label] || !strings.HasPrefix(c.label, label) {
+				continue
+			}
+			matched[label] = true
+			if c.checked != on && (on || !c.radio) {
+				keys.WriteString(strings.Repeat("\t", i-pos) + " ") // Tab, Space
+				pos = i
+			}
+			break
+		}
+	}
+	for j, f := range fields {
+		if f == "" {
+			continue
+		}
+		i := len(controls) + j
+		keys.WriteString(strings.Repeat("\t", i-pos) + "\025" + f) // Tab, ^U
+		pos = i
+	}
+	keys.WriteString("\n") // Enter accepts the dialog.
+	b.c.Send(keys.String())
+	b.s = stateIdle
+	b.menuName = ""
+	return nil
+}
+
+func hasControl(controls []control, label string) bool {
+	for _, c := range controls {
+		if strings.HasPrefix(c.label, label) {
+			return true
+		}
+	}
+	return false
+}
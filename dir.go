@@ -0,0 +1,103 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DirEntry is an entry of a directory listing, as shown by links2 for FTP
+// and local directories.
+type DirEntry struct {
+	Name    string
+	Dir     bool   // Dir is set for directories.
+	Mode    string // Mode is the ls style mode, e.g. "drwxr-xr-x".
+	Size    int64
+	ModTime string // ModTime is the modification time as listed, e.g. "Jan  2 15:04".
+	Target  string // Target is the target of a symbolic link, if any.
+	URL     string // URL is the URL of the entry, resolved against the listing.
+}
+
+// listingPattern matches an ls -l style listing line.
+var listingPattern = regexp.MustCompile(`^([-dlcbps][-rwxsStT]{9})\S*\s+\d+\s+\S+\s+\S+\s+(\d+)\s+(\w{3}\s+\d+\s+[\d:]+)\s+(.+)$`)
+
+// DirEntries returns the entries of the directory listing in the current
+// document, e.g. after navigating to an ftp:// URL or a local directory. Lines which are not
+// listing entries, like the link to the parent directory, are skipped.
+func (b *Browser) DirEntries() ([]DirEntry, error) {
+	return b.DirEntriesContext(context.Background())
+}
+
+// DirEntriesContext is like DirEntries but bounds waits by ctx.
+func (b *Browser) DirEntriesContext(ctx context.Context) ([]DirEntry, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	text, err := b.formattedDocument(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base, _ := url.Parse(b.lastURL)
+	return parseListing(text, base), nil
+}
+
+// OpenEntry navigates to the entry of the current directory listing with
+// the given name, as returned by DirEntries. If there's no such entry the
+// error wraps ErrNoSuchFile.
+func (b *Browser) OpenEntry(name string) (NavigateResult, error) {
+	return b.OpenEntryContext(context.Background(), name)
+}
+
+// OpenEntryContext is like OpenEntry but bounds waits by ctx.
+func (b *Browser) OpenEntryContext(ctx context.Context, name string) (NavigateResult, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	entries, err := b.DirEntriesContext(ctx)
+	if err != nil {
+		return NavigateResult{}, err
+	}
+	for _, e := range entries {
+		if e.Name == name && e.URL != "" {
+			return b.NavigateContext(ctx, e.URL)
+		}
+	}
+	return NavigateResult{}, fmt.Errorf("open entry %q: %w", name, ErrNoSuchFile)
+}
+
+// parseListing parses the entries of a listing, resolving their URLs
+// against base if it's not nil.
+func parseListing(text string, base *url.URL) []DirEntry {
+	if base != nil && !strings.HasSuffix(base.Path, "/") {
+		u := *base
+		u.Path += "/"
+		base = &u
+	}
+	var entries []DirEntry
+	for _, line := range strings.Split(text, "\n") {
+		m := listingPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		e := DirEntry{Mode: m[1], Dir: m[1][0] == 'd', ModTime: m[3], Name: m[4]}
+		e.Size, _ = strconv.ParseInt(m[2], 10, 64)
+		if m[1][0] == 'l' {
+			e.Name, e.Target, _ = strings.Cut(e.Name, " -> ")
+		}
+		if e.Name == "." || e.Name == ".." {
+			continue
+		}
+		if base != nil {
+			name := (&url.URL{Path: e.Name}).EscapedPath()
+			if e.Dir {
+				name += "/"
+			}
+			if u, err := base.Parse("./" + name); err == nil {
+				e.URL = u.String()
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
@@ -0,0 +1,363 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DownloadStatus is the progress of a download as shown in its Download dialog.
+type DownloadStatus struct {
+	URL      string
+	Received int64         // Received is the number of bytes received so far.
+	Total    int64         // Total is the size of the download in bytes or -1 if unknown.
+	Percent  int           // Percent complete or -1 if unknown.
+	ETA      time.Duration // ETA is the estimated time remaining or 0 if unknown.
+	Done     bool          // Done is set once the download is no longer listed in the Downloads menu.
+}
+
+// Download is a background download started by DownloadLink.
+type Download struct {
+	b      *Browser
+	Path   string
+	URL    string
+	status DownloadStatus
+	done   chan struct{}
+}
+
+// Done returns a channel which is closed when the download completes or is cancelled.
+// Completion is only detected when the download status is refreshed by Progress,
+// Wait or Downloads, so nothing closes the channel unless one of them is called.
+func (d *Download) Done() <-chan struct{} { return d.done }
+
+// Progress refreshes and returns the status of the download.
+func (d *Download) Progress() (DownloadStatus, error) {
+	return d.ProgressContext(context.Background())
+}
+
+// ProgressContext is like Progress but bounds waits by ctx.
+func (d *Download) ProgressContext(ctx context.Context) (DownloadStatus, error) {
+	ctx, done := d.b.begin(ctx)
+	defer done()
+	if _, err := d.b.DownloadsContext(ctx); err != nil {
+		return DownloadStatus{}, err
+	}
+	return d.status, nil
+}
+
+// Wait polls the download status every interval, as Progress does, until the
+// download completes or ctx is done.
+func (d *Download) Wait(ctx context.Context, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if _, err := d.ProgressContext(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-d.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Cancel aborts the download. The partially downloaded file is kept.
+func (d *Download) Cancel() error {
+	return d.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel but bounds waits by ctx.
+func (d *Download) CancelContext(ctx context.Context) error {
+	ctx, done := d.b.begin(ctx)
+	defer done()
+	select {
+	case <-d.done:
+		return nil
+	default:
+	}
+	statuses, err := d.b.DownloadsContext(ctx)
+	if err != nil {
+		return err
+	}
+	for i, s := range statuses {
+		if s.URL == d.URL {
+			if err := d.b.openDownload(ctx, i); err != nil {
+				return err
+			}
+			d.b.c.Send("\t\t\n") // [ Abort ]
+			d.b.s = stateIdle
+			d.b.menuName = ""
+			d.b.untrack(d)
+			d.finish()
+			return nil
+		}
+	}
+	return nil
+}
+
+// finish marks the download done. It may be called again, e.g. for a
+// cancelled download no longer listed.
+func (d *Download) finish() {
+	if d.status.Done {
+		return
+	}
+	d.status.Done = true
+	close(d.done)
+	d.b.events.emit(Event{Kind: EventDownloadFinish, URL: d.URL})
+}
+
+// DownloadLink downloads the target of the selected link to path in the background.
+//
+// If path exists the download is not started and ErrFileExists is returned.
+// A relative path is resolved against the directory of WithDownloadDir, if any.
+func (b *Browser) DownloadLink(path string) (*Download, error) {
+	return b.DownloadLinkContext(context.Background(), path)
+}
+
+// DownloadLinkContext is like DownloadLink but bounds waits by ctx.
+func (b *Browser) DownloadLinkContext(ctx context.Context, path string) (*Download, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.checkAnonymous("download"); err != nil {
+		return nil, err
+	}
+	if err := checkInput(path); err != nil {
+		return nil, err
+	}
+	path = b.resolvePath(path)
+	link, err := b.CurrentLinkContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.perform(ActionDownload); err != nil {
+		return nil, err
+	}
+	b.s = stateMenu
+	b.menuName = menuDownload
+	return b.startDownload(path, link.URL)
+}
+
+// DownloadURL downloads the document at rawURL to path in the background,
+// as DownloadLink does for links. It's meant for documents links2 can't
+// display, like images in text mode, which links2 offers to save. Other
+// documents are displayed, so DownloadURL goes back and returns an error.
+// The current document is left as it is either way.
+func (b *Browser) DownloadURL(rawURL, path string) (*Download, error) {
+	return b.DownloadURLContext(context.Background(), rawURL, path)
+}
+
+// DownloadURLContext is like DownloadURL but bounds waits by ctx.
+func (b *Browser) DownloadURLContext(ctx context.Context, rawURL, path string) (*Download, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.checkAnonymous("download"); err != nil {
+		return nil, err
+	}
+	if err := checkInput(path); err != nil {
+		return nil, err
+	}
+	lastURL := b.lastURL
+	b.saveAs = b.resolvePath(path)
+	defer func() { b.saveAs, b.lastURL = "", lastURL }()
+	res, err := b.NavigateContext(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if res.Download == nil {
+		// Go back to the document left.
+		if err := b.sendLoad(ActionBack); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("download %s: document was displayed", res.urlString())
+	}
+	return res.Download, nil
+}
+
+// startDownload fills in the open Download dialog to download url to path
+// and moves the download to the background.
+func (b *Browser) startDownload(path, url string) (*Download, error) {
+	if _, err := b.expectString(downloadDialog); err != nil {
+		return nil, err
+	}
+	// Replace the suggested file name.
+	b.c.Send("\025" + path + "\n") // ^U
+	buf, err := b.expect(b.timeouts.Menu, b.match(fileAlreadyExists, downloadReceived))
+	if err != nil {
+		return nil, err
+	}
+	if b.matched(buf, fileAlreadyExists) {
+		b.c.Send("\033") // Esc
+		return nil, fmt.Errorf("download %s: %w", path, ErrFileExists)
+	}
+	// Move the Download dialog to the background.
+	b.closeMenu()
+	d := &Download{
+		b:      b,
+		Path:   path,
+		URL:    url,
+		status: DownloadStatus{URL: url, Total: -1, Percent: -1},
+		done:   make(chan struct{}),
+	}
+	b.downloads = append(b.downloads, d)
+	return d, nil
+}
+
+// Downloads returns the status of every download listed in the Downloads menu.
+// Downloads started with DownloadLink which are no longer listed are marked done.
+func (b *Browser) Downloads() ([]DownloadStatus, error) {
+	return b.DownloadsContext(context.Background())
+}
+
+// DownloadsContext is like Downloads but bounds waits by ctx.
+func (b *Browser) DownloadsContext(ctx context.Context) ([]DownloadStatus, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	urls, err := b.downloadURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]DownloadStatus, 0, len(urls))
+	for i := range urls {
+		if err := b.openDownload(ctx, i); err != nil {
+			return nil, err
+		}
+		raw, err := b.drain()
+		b.closeMenu()
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, parseDownloadStatus(urls[i], dialogLines(raw)))
+	}
+	b.updateDownloads(statuses)
+	return statuses, nil
+}
+
+// updateDownloads refreshes the tracked downloads from statuses.
+func (b *Browser) updateDownloads(statuses []DownloadStatus) {
+	active := b.downloads[:0]
+	for _, d := range b.downloads {
+		found := false
+		for _, s := range statuses {
+			if s.URL == d.URL {
+				d.status, found = s, true
+				break
+			}
+		}
+		if !found {
+			d.finish()
+			continue
+		}
+		active = append(active, d)
+	}
+	b.downloads = active
+}
+
+// untrack stops tracking d.
+func (b *Browser) untrack(d *Download) {
+	for i, t := range b.downloads {
+		if t == d {
+			b.downloads = append(b.downloads[:i], b.downloads[i+1:]...)
+			return
+		}
+	}
+}
+
+// downloadURLs opens the Downloads menu and returns the listed URLs.
+func (b *Browser) downloadURLs(ctx context.Context) ([]string, error) {
+	defer b.closeMenu()
+	if err := b.OpenMenuContext(ctx, "Downloads"); err != nil {
+		return nil, err
+	}
+	raw, err := b.drain()
+	if err != nil {
+		return nil, err
+	}
+	var urls []string
+	for _, line := range dialogLines(raw) {
+		if line == b.tr(noDownloads) {
+			return nil, nil
+		}
+		if strings.Contains(line, "://") {
+			urls = append(urls, line)
+		}
+	}
+	return urls, nil
+}
+
+// openDownload opens the Download dialog of the i-th entry of the Downloads menu.
+func (b *Browser) openDownload(ctx context.Context, i int) error {
+	if err := b.OpenMenuContext(ctx, "Downloads"); err != nil {
+		return err
+	}
+	for ; i > 0; i-- {
+		if err := b.press(ActionNextItem); err != nil {
+			return err
+		}
+	}
+	if err := b.press(ActionSelect); err != nil {
+		return err
+	}
+	b.menuName = menuDownload
+	_, err := b.expectString(downloadReceived)
+	return err
+}
+
+var (
+	receivedRE = regexp.MustCompile(`Received ([0-9.]+ ?[kMG]?)B?(?: of ([0-9.]+ ?[kMG]?)B?)?`)
+	percentRE  = regexp.MustCompile(`(\d+) ?%`)
+	etaRE      = regexp.MustCompile(`[Ee]stimated time:? ((?:\d+:)?\d+:\d+)`)
+)
+
+// parseDownloadStatus parses the lines of a Download dialog.
+// Missing or malformed fields are left unknown.
+func parseDownloadStatus(url string, lines []string) DownloadStatus {
+	s := DownloadStatus{URL: url, Total: -1, Percent: -1}
+	text := strings.Join(lines, " ")
+	if m := receivedRE.FindStringSubmatch(text); m != nil {
+		s.Received = parseByteSize(m[1])
+		if m[2] != "" {
+			s.Total = parseByteSize(m[2])
+		}
+	}
+	if m := percentRE.FindStringSubmatch(text); m != nil {
+		s.Percent, _ = strconv.Atoi(m[1])
+	} else if s.Total > 0 {
+		s.Percent = int(100 * s.Received / s.Total)
+	}
+	if m := etaRE.FindStringSubmatch(text); m != nil {
+		s.ETA = parseClock(m[1])
+	}
+	return s
+}
+
+// parseByteSize parses sizes like "1234", "12 k" or "1.5 M".
+func parseByteSize(s string) int64 {
+	s = strings.TrimSpace(s)
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(s, "k"):
+		mult = 1 << 10
+	case strings.HasSuffix(s, "M"):
+		mult = 1 << 20
+	case strings.HasSuffix(s, "G"):
+		mult = 1 << 30
+	}
+	f, _ := strconv.ParseFloat(strings.TrimSpace(strings.TrimRight(s, "kMG")), 64)
+	return int64(f * mult)
+}
+
+// parseClock parses durations like "1:05" or "2:01:05".
+func parseClock(s string) time.Duration {
+	var d time.Duration
+	for _, f := range strings.Split(s, ":") {
+		n, _ := strconv.Atoi(f)
+		d = d*60 + time.Duration(n)
+	}
+	return d * time.Second
+}
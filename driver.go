@@ -0,0 +1,241 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Action is a browser command bound to a key by a Driver.
+type Action int
+
+const (
+	ActionGoTo Action = iota
+	ActionBack
+	ActionForward
+	ActionReload
+	ActionMenu // ActionMenu opens the dropdown menu.
+	ActionInfo
+	ActionHeader
+	ActionViewSource // ActionViewSource toggles the HTML source view.
+	ActionSearch
+	ActionSearchBackward
+	ActionFindNext
+	ActionFindPrevious
+	ActionScrollUp
+	ActionScrollDown
+	ActionScrollLeft
+	ActionScrollRight
+	ActionNextLink
+	ActionPrevLink
+	ActionFollowLink
+	ActionHome
+	ActionEnd
+	ActionBookmarks
+	ActionDownload
+	ActionQuit
+	ActionStop // ActionStop aborts the page load in progress.
+	ActionNextFrame
+	ActionNextMenu    // ActionNextMenu selects the next menu of the menu bar.
+	ActionNextItem    // ActionNextItem moves the selection of a menu down.
+	ActionFirstItem   // ActionFirstItem selects the first item of a menu.
+	ActionSelect      // ActionSelect activates the selected menu item or focused control.
+	ActionNextControl // ActionNextControl focuses the next control of a dialog.
+)
+
+var actionNames = [...]string{
+	ActionGoTo:           "go to",
+	ActionBack:           "back",
+	ActionForward:        "forward",
+	ActionReload:         "reload",
+	ActionMenu:           "menu",
+	ActionInfo:           "info",
+	ActionHeader:         "header",
+	ActionViewSource:     "view source",
+	ActionSearch:         "search",
+	ActionSearchBackward: "search backward",
+	ActionFindNext:       "find next",
+	ActionFindPrevious:   "find previous",
+	ActionScrollUp:       "scroll up",
+	ActionScrollDown:     "scroll down",
+	ActionScrollLeft:     "scroll left",
+	ActionScrollRight:    "scroll right",
+	ActionNextLink:       "next link",
+	ActionPrevLink:       "previous link",
+	ActionFollowLink:     "follow link",
+	ActionHome:           "home",
+	ActionEnd:            "end",
+	ActionBookmarks:      "bookmarks",
+	ActionDownload:       "download",
+	ActionQuit:           "quit",
+	ActionStop:           "stop",
+	ActionNextFrame:      "next frame",
+	ActionNextMenu:       "next menu",
+	ActionNextItem:       "next item",
+	ActionFirstItem:      "first item",
+	ActionSelect:         "select",
+	ActionNextControl:    "next control",
+}
+
+func (a Action) String() string {
+	if a < 0 || int(a) >= len(actionNames) {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+// Keymap maps actions to the keys performing them.
+type Keymap map[Action]string
+
+// WithKeymap performs the actions in km with their keys instead of those of
+// the Driver, e.g. for a browser whose keys were rebound by its
+// configuration. Actions not in km keep the keys of the Driver. Keys which
+// should open a menu or dialog but don't are reported by ErrKeymap.
+func WithKeymap(km Keymap) Option {
+	return func(o *options) error {
+		for a, keys := range km {
+			if keys == "" {
+				return fmt.Errorf("keymap: no keys for action %v", a)
+			}
+		}
+		o.keymap = km
+		return nil
+	}
+}
+
+// Driver describes a TUI browser driven by a Browser: how to run it, which
+// keys perform each Action and how its UI text differs from links2.
+//
+// The state machine and menu logic assume the links2 UI, so a Driver for
+// another browser supports only the operations its UI has an equivalent for.
+// Links2 is the only Driver provided.
+type Driver interface {
+	// Command returns the program run by Open and its leading arguments.
+	Command() (name string, args []string)
+	// Keys returns the keys performing a, or "" if the browser has no such command.
+	Keys(a Action) string
+	// Patterns translates the links2 UI text matched by the package.
+	Patterns() Patterns
+}
+
+// Links2 is the default Driver.
+var Links2 Driver = &keymapDriver{name: "links2", keys: links2Keys}
+
+// WithDriver drives the browser described by d instead of links2.
+func WithDriver(d Driver) Option {
+	return func(o *options) error {
+		o.driver = d
+		return nil
+	}
+}
+
+// keymapDriver is a Driver defined by tables.
+type keymapDriver struct {
+	name     string
+	keys     map[Action]string
+	patterns Patterns
+}
+
+func (d *keymapDriver) Command() (string, []string) { return d.name, nil }
+func (d *keymapDriver) Keys(a Action) string        { return d.keys[a] }
+func (d *keymapDriver) Patterns() Patterns          { return d.patterns }
+
+var links2Keys = map[Action]string{
+	ActionGoTo:           "g",
+	ActionBack:           "\033[D", // Left
+	ActionForward:        "u",
+	ActionReload:         "\022", // ^R
+	ActionMenu:           "\033", // Esc
+	ActionInfo:           "=",
+	ActionHeader:         "|",
+	ActionViewSource:     "\\",
+	ActionSearch:         "/",
+	ActionSearchBackward: "?",
+	ActionFindNext:       "n",
+	ActionFindPrevious:   "N",
+	ActionScrollUp:       "\033[5~", // PgUp
+	ActionScrollDown:     "\033[6~", // PgDn
+	ActionScrollLeft:     "[",
+	ActionScrollRight:    "]",
+	ActionNextLink:       "\033[B", // Down
+	ActionPrevLink:       "\033[A", // Up
+	ActionFollowLink:     "\033[C", // Right
+	ActionHome:           "\033[H",
+	ActionEnd:            "\033[F",
+	ActionBookmarks:      "s",
+	ActionDownload:       "d",
+	ActionQuit:           "\003", // ^C
+	ActionStop:           "z",
+	ActionNextFrame:      "\t",
+	ActionNextMenu:       "\033[C", // Right
+	ActionNextItem:       "\033[B", // Down
+	ActionFirstItem:      "\033[H", // Home
+	ActionSelect:         "\n",     // Enter
+	ActionNextControl:    "\t",
+}
+
+// keys returns the keys of action a for the running driver.
+func (b *Browser) keys(a Action) (string, error) {
+	d := Links2
+	if b.opts != nil {
+		if keys, ok := b.opts.keymap[a]; ok {
+			return keys, nil
+		}
+		if b.opts.driver != nil {
+			d = b.opts.driver
+		}
+	}
+	keys := d.Keys(a)
+	if keys == "" {
+		name, _ := d.Command()
+		return "", fmt.Errorf("%s: action %v: %w", name, a, errors.ErrUnsupported)
+	}
+	return keys, nil
+}
+
+// perform closes any open menu and sends the keys of action a.
+func (b *Browser) perform(a Action) error {
+	keys, err := b.keys(a)
+	if err != nil {
+		return err
+	}
+	return b.sendIdle(keys)
+}
+
+// press sends the keys of action a to the open menu or dialog.
+func (b *Browser) press(a Action) error {
+	keys, err := b.keys(a)
+	if err != nil {
+		return err
+	}
+	_, err = b.c.Send(keys)
+	return err
+}
+
+// performN closes any open menu and sends the keys of action a n times in
+// a single write, then waits for links2 to redraw.
+func (b *Browser) performN(a Action, n int) error {
+	if n < 0 {
+		return fmt.Errorf("invalid repeat count: %d", n)
+	}
+	keys, err := b.keys(a)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return nil
+	}
+	if err := b.sendIdle(strings.Repeat(keys, n)); err != nil {
+		return err
+	}
+	_, err = b.drain()
+	return err
+}
+
+// action performs a as a single operation.
+func (b *Browser) action(a Action) error {
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.perform(a)
+}
@@ -0,0 +1,46 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Dump returns the formatted text of the page at url using links2 -dump.
+// No PTY is needed. If width is positive, the page is formatted for a
+// terminal of that width.
+func Dump(ctx context.Context, url string, width int) (string, error) {
+	args := []string{"-dump"}
+	if width > 0 {
+		args = append(args, "-width", strconv.Itoa(width))
+	}
+	out, err := run(ctx, url, args...)
+	return string(out), err
+}
+
+// FetchSource returns the raw source of the page at url using links2 -source.
+// No PTY is needed.
+func FetchSource(ctx context.Context, url string) ([]byte, error) {
+	return run(ctx, url, "-source")
+}
+
+// run runs links2 non-interactively with args and url and returns its output.
+func run(ctx context.Context, url string, args ...string) ([]byte, error) {
+	if strings.HasPrefix(url, "-") {
+		return nil, fmt.Errorf("url must not begin with a dash: %q", url)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "links2", append(args, url)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("links2 %s: %w: %s", args[0], err, msg)
+		}
+		return nil, fmt.Errorf("links2 %s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,97 @@
+package links2
+
+import (
+	"strings"
+	"sync"
+)
+
+// maxErrorDialog bounds the output kept for an error dialog being drawn.
+const maxErrorDialog = 4096
+
+// errorWatch watches all output read from links2 for error dialogs, so that
+// dialogs which appear between operations, e.g. when a background download
+// fails, can be dismissed before they swallow the keys of the next operation.
+//
+// Dialogs are seen as their output is read, which happens in the background.
+type errorWatch struct {
+	mu       sync.Mutex
+	title    string // title starts an error dialog.
+	ok       string // ok ends it.
+	tail     string // tail holds output which may start the title.
+	drawing  bool
+	raw      strings.Builder
+	messages []string // messages of dialogs drawn and not yet taken.
+}
+
+func newErrorWatch(title, ok string) *errorWatch {
+	return &errorWatch{title: title, ok: ok}
+}
+
+func (w *errorWatch) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	s := w.tail + string(p)
+	w.tail = ""
+	for s != "" {
+		if !w.drawing {
+			i := strings.Index(s, w.title)
+			if i < 0 {
+				w.tail = partialSuffix(s, w.title)
+				break
+			}
+			s = s[i+len(w.title):]
+			w.drawing = true
+			w.raw.Reset()
+			continue
+		}
+		w.raw.WriteString(s)
+		raw := w.raw.String()
+		i := strings.Index(raw, w.ok)
+		if i < 0 {
+			if w.raw.Len() > maxErrorDialog {
+				w.drawing = false
+			}
+			break
+		}
+		w.messages = append(w.messages, strings.Join(dialogLines(raw[:i]), " "))
+		w.drawing = false
+		s = raw[i+len(w.ok):]
+	}
+	return len(p), nil
+}
+
+// partialSuffix returns the longest suffix of s which is a proper prefix of
+// pattern, to be matched again with the next output.
+func partialSuffix(s, pattern string) string {
+	for n := min(len(s), len(pattern)-1); n > 0; n-- {
+		if strings.HasSuffix(s, pattern[:n]) {
+			return s[len(s)-n:]
+		}
+	}
+	return ""
+}
+
+// take returns the messages of the error dialogs drawn since the last call.
+func (w *errorWatch) take() []string {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	msgs := w.messages
+	w.messages = nil
+	return msgs
+}
+
+// dismissErrors closes the error dialogs drawn while no menu or dialog was
+// expected. Their messages are reported as EventErrorDialog events.
+func (b *Browser) dismissErrors() error {
+	for _, msg := range b.errDialogs.take() {
+		b.log.Warn("dismiss error dialog", "message", msg)
+		b.events.emit(Event{Kind: EventErrorDialog, URL: b.lastURL, Message: msg})
+		if _, err := b.c.Send("\033"); err != nil { // Esc
+			return err
+		}
+	}
+	return nil
+}
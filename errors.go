@@ -0,0 +1,60 @@
+package links2
+
+import "errors"
+
+// Navigation errors reported by links2 error dialogs.
+var (
+	ErrHostNotFound = errors.New("host not found")
+	ErrSSLFailure   = errors.New("ssl failure")
+	ErrNoSuchFile   = errors.New("no such file or directory")
+	ErrLoading      = errors.New("error loading")
+)
+
+// Browser state errors.
+var (
+	ErrNotStarted     = errors.New("browser not started")
+	ErrAlreadyStarted = errors.New("browser already started")
+	ErrMenuOpen       = errors.New("menu could not be closed")
+	// ErrClosed is returned by screen waits interrupted by Close or Quit.
+	ErrClosed = errors.New("browser closed")
+)
+
+// ErrTimeout is returned when links2 doesn't draw an expected pattern within
+// the timeout. It wraps os.ErrDeadlineExceeded too.
+var ErrTimeout = errors.New("timed out waiting for links2")
+
+// ErrFileExists is returned when saving to a file which already exists.
+var ErrFileExists = errors.New("file already exists")
+
+// ErrAuthRequired is returned when a page requires HTTP authentication and
+// no credentials were given for it.
+var ErrAuthRequired = errors.New("authentication required")
+
+// ErrUnsafeInput is returned for text which cannot be typed into links2
+// safely because links2 would read parts of it as key presses.
+var ErrUnsafeInput = errors.New("unsafe input")
+
+// ErrKeymap is returned when the keys of an action don't open the menu or
+// dialog expected, e.g. because they're bound differently, see WithKeymap.
+var ErrKeymap = errors.New("keys did not open the expected menu")
+
+// ErrNoLink is returned when no link is selected.
+var ErrNoLink = errors.New("no link selected")
+
+// loadingError classifies the raw contents of an error dialog.
+// Unrecognized errors are reported as ErrLoading.
+func (b *Browser) loadingError(raw string) error {
+	switch {
+	case b.contains(raw, hostNotFound):
+		return ErrHostNotFound
+	case b.contains(raw, noSuchFile):
+		return ErrNoSuchFile
+	case b.contains(raw, sslError):
+		return ErrSSLFailure
+	default:
+		return ErrLoading
+	}
+}
+
+// ErrProcessExited is returned when the links2 process exited unexpectedly.
+var ErrProcessExited = errors.New("links2 process exited")
@@ -0,0 +1,114 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EventKind identifies the kind of an Event.
+type EventKind int
+
+const (
+	EventNavigateStart  EventKind = iota // EventNavigateStart a URL was entered in the Go to URL dialog.
+	EventNavigateFinish                  // EventNavigateFinish a page load finished or failed; Err is set on failure.
+	EventErrorDialog                     // EventErrorDialog an error dialog appeared; Message holds its text.
+	EventDownloadFinish                  // EventDownloadFinish a download completed or was cancelled.
+	EventProcessExit                     // EventProcessExit the links2 process exited; Err holds the exit error.
+	EventDocumentChange                  // EventDocumentChange a document watched by Watch changed; Err is set if it could not be checked.
+)
+
+var eventKindNames = [...]string{
+	EventNavigateStart:  "navigate start",
+	EventNavigateFinish: "navigate finish",
+	EventErrorDialog:    "error dialog",
+	EventDownloadFinish: "download finish",
+	EventProcessExit:    "process exit",
+	EventDocumentChange: "document change",
+}
+
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventKindNames) {
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+	return eventKindNames[k]
+}
+
+// Event is something that happened in the browser.
+type Event struct {
+	Kind    EventKind
+	Time    time.Time
+	URL     string // URL of the navigation or download, if any.
+	Message string // Message is the text of an error dialog.
+	Err     error
+}
+
+// eventBuffer is the capacity of each event channel. Events are dropped for
+// subscribers which fall this far behind, so operations never block on them.
+const eventBuffer = 64
+
+// eventHub fans out events to subscribers. It has its own lock so events
+// can be emitted from outside operations, e.g. when the process exits.
+type eventHub struct {
+	mu     sync.Mutex
+	subs   []chan Event
+	closed bool
+}
+
+func (h *eventHub) subscribe() <-chan Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan Event, eventBuffer)
+	if h.closed {
+		close(ch)
+		return ch
+	}
+	h.subs = append(h.subs, ch)
+	return ch
+}
+
+func (h *eventHub) emit(e Event) {
+	if h == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+func (h *eventHub) close() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for _, ch := range h.subs {
+		close(ch)
+	}
+	h.subs = nil
+}
+
+// Events returns a new channel of browser events. The channel is closed when
+// the browser is closed. Events are dropped if the channel is not drained.
+// Events returns nil if the browser is not started.
+func (b *Browser) Events() <-chan Event {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.events == nil {
+		return nil
+	}
+	return b.events.subscribe()
+}
@@ -0,0 +1,237 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ExitKind is how the links2 process exited.
+type ExitKind int
+
+const (
+	ExitClean    ExitKind = iota // ExitClean links2 exited with status 0.
+	ExitFailed                   // ExitFailed links2 exited with a non-zero status.
+	ExitSignaled                 // ExitSignaled links2 was killed by a signal not sent by the Browser.
+	ExitForced                   // ExitForced links2 was killed by Close or the cancelled context of Open.
+)
+
+var exitKindNames = [...]string{
+	ExitClean:    "clean",
+	ExitFailed:   "failed",
+	ExitSignaled: "signaled",
+	ExitForced:   "forced",
+}
+
+func (k ExitKind) String() string {
+	if k < 0 || int(k) >= len(exitKindNames) {
+		return fmt.Sprintf("ExitKind(%d)", int(k))
+	}
+	return exitKindNames[k]
+}
+
+// Exit describes how the links2 process exited.
+type Exit struct {
+	Kind ExitKind
+	Code int   // Code is the exit status, or -1 if links2 was killed.
+	Err  error // Err is the error of waiting for the process, nil for a clean exit.
+}
+
+// exitStatus is the result of waiting for the links2 process.
+type exitStatus struct {
+	done   chan struct{} // done is closed when the process exits.
+	err    error         // err is the result of cmd.Wait once done is closed.
+	state  *os.ProcessState
+	forced atomic.Bool // forced is set when the Browser kills the process.
+}
+
+// monitor waits for cmd in the background. When it exits, tty is closed so
+// that pending expect reads fail instead of blocking forever, and an
+// EventProcessExit is emitted.
+func (e *exitStatus) monitor(cmd *exec.Cmd, tty *os.File, events *eventHub) {
+	go func() {
+		e.err = cmd.Wait()
+		e.state = cmd.ProcessState
+		close(e.done)
+		tty.Close()
+		events.emit(Event{Kind: EventProcessExit, Err: e.err})
+	}()
+}
+
+// killGrace is how long links2 and its children have to exit after SIGTERM
+// before they're killed.
+const killGrace = 2 * time.Second
+
+// terminate stops cmd when the Browser is closed or the context of Open is
+// done: the process group is sent SIGTERM, and after killGrace anything left
+// of it is killed, including children which outlive links2.
+func (e *exitStatus) terminate(cmd *exec.Cmd) error {
+	select {
+	case <-e.done:
+		signalGroup(cmd, true) // Orphans.
+		return os.ErrProcessDone
+	default:
+	}
+	e.forced.Store(true)
+	err := signalGroup(cmd, false)
+	go func() {
+		t := time.NewTimer(killGrace)
+		defer t.Stop()
+		select {
+		case <-e.done:
+		case <-t.C:
+		}
+		signalGroup(cmd, true)
+	}()
+	return err
+}
+
+// result returns how the process exited once done is closed.
+func (e *exitStatus) result() Exit {
+	x := Exit{Code: -1, Err: e.err}
+	if e.state != nil {
+		x.Code = e.state.ExitCode()
+	}
+	switch {
+	case x.Code == 0 && e.err == nil:
+		x.Kind = ExitClean
+	case e.forced.Load():
+		x.Kind = ExitForced
+	case x.Code == -1:
+		x.Kind = ExitSignaled
+	default:
+		x.Kind = ExitFailed
+	}
+	return x
+}
+
+// ExitStatus returns how the last links2 process exited, including after
+// Close. It returns false if the process is still running or was never
+// started.
+func (b *Browser) ExitStatus() (Exit, bool) {
+	_, done := b.begin(context.Background())
+	defer done()
+	e := b.proc.exit
+	if e == nil {
+		return Exit{}, false
+	}
+	select {
+	case <-e.done:
+		return e.result(), true
+	default:
+		return Exit{}, false
+	}
+}
+
+// Stderr returns the end of what the last links2 process wrote to its
+// standard error, which is kept apart from the terminal.
+func (b *Browser) Stderr() string {
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.proc.stderr.String()
+}
+
+// maxTail bounds the output kept by a tailBuffer.
+const maxTail = 64 << 10
+
+// tailBuffer keeps the last maxTail bytes written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (s *tailBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf = append(s.buf, p...)
+	if n := len(s.buf) - maxTail; n > 0 {
+		s.buf = append(s.buf[:0], s.buf[n:]...)
+	}
+	return len(p), nil
+}
+
+// String returns the contents of s, or "" for a nil s.
+func (s *tailBuffer) String() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return string(s.buf)
+}
+
+// lastLine returns the last non-empty line of s.
+func (s *tailBuffer) lastLine() string {
+	lines := strings.Split(strings.TrimSpace(s.String()), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// WithRestart restarts links2 if it exits unexpectedly, navigating back to
+// the URL last loaded by Navigate. The restart happens at the start of the
+// next operation.
+func WithRestart() Option {
+	return func(o *options) error {
+		o.restart = true
+		return nil
+	}
+}
+
+// Exited returns a channel which is closed when the links2 process exits,
+// or nil if the browser is not started. Operations on a browser whose process
+// exited return ErrProcessExited unless WithRestart was given.
+func (b *Browser) Exited() <-chan struct{} {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.exit == nil {
+		return nil
+	}
+	return b.exit.done
+}
+
+// checkExited returns ErrProcessExited if the process exited, after trying
+// to restart it when WithRestart was given.
+func (b *Browser) checkExited() error {
+	if b.exit == nil {
+		return nil
+	}
+	select {
+	case <-b.exit.done:
+	default:
+		return nil
+	}
+	err := fmt.Errorf("%w: %v", ErrProcessExited, b.exit.err)
+	if line := b.proc.stderr.lastLine(); line != "" {
+		err = fmt.Errorf("%w: %s", err, line)
+	}
+	if !b.opts.restart {
+		return err
+	}
+	if rerr := b.restart(); rerr != nil {
+		return fmt.Errorf("%w; restart: %v", err, rerr)
+	}
+	return nil
+}
+
+// restart replaces the exited process with a new one and navigates back to
+// the last URL.
+func (b *Browser) restart() error {
+	o, lastURL, ctx, events, home := b.opts, b.lastURL, b.ctx, b.events, b.home
+	scratch, htmlDocs, headerProxy, cast := b.scratch, b.htmlDocs, b.headerProxy, b.cast
+	b.c.Close()
+	b.instance = instance{ctx: ctx, events: events, home: home, scratch: scratch, htmlDocs: htmlDocs, headerProxy: headerProxy, cast: cast}
+	if err := b.start(context.Background(), o); err != nil {
+		// The Browser is left closed.
+		b.release()
+		return err
+	}
+	if lastURL == "" {
+		return nil
+	}
+	_, err := b.NavigateContext(ctx, lastURL)
+	return err
+}
@@ -0,0 +1,79 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Match is a match of FindAll in the formatted document.
+type Match struct {
+	Text   string
+	Line   int // Line is the 0-based document line the match starts on.
+	Column int // Column is the 0-based column, in runes, the match starts at.
+}
+
+// FindAll returns the matches of re in the formatted document, as saved by
+// SaveFormattedDocument, in order. Matches may span lines. The matches are
+// kept for GoToMatch.
+func (b *Browser) FindAll(re *regexp.Regexp) ([]Match, error) {
+	return b.FindAllContext(context.Background(), re)
+}
+
+// FindAllContext is like FindAll but bounds waits by ctx.
+func (b *Browser) FindAllContext(ctx context.Context, re *regexp.Regexp) ([]Match, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	doc, err := b.formattedDocument(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.matches = findAll(re, doc)
+	return b.matches, nil
+}
+
+// findAll returns the matches of re in doc with their positions.
+func findAll(re *regexp.Regexp, doc string) []Match {
+	var (
+		matches []Match
+		line    int
+		start   int // start is the offset of line in doc.
+		pos     int // pos is the offset line and start were counted to.
+	)
+	for _, loc := range re.FindAllStringIndex(doc, -1) {
+		for {
+			i := strings.IndexByte(doc[pos:loc[0]], '\n')
+			if i < 0 {
+				break
+			}
+			line++
+			pos += i + 1
+			start = pos
+		}
+		pos = loc[0]
+		matches = append(matches, Match{
+			Text:   doc[loc[0]:loc[1]],
+			Line:   line,
+			Column: utf8.RuneCountInString(doc[start:loc[0]]),
+		})
+	}
+	return matches
+}
+
+// GoToMatch scrolls the current document so the line of match i of the
+// last FindAll is at the top of the screen, as GoToLine does.
+func (b *Browser) GoToMatch(i int) error {
+	return b.GoToMatchContext(context.Background(), i)
+}
+
+// GoToMatchContext is like GoToMatch but bounds waits by ctx.
+func (b *Browser) GoToMatchContext(ctx context.Context, i int) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if i < 0 || i >= len(b.matches) {
+		return fmt.Errorf("go to match: no match %d of %d", i, len(b.matches))
+	}
+	return b.GoToLineContext(ctx, b.matches[i].Line)
+}
@@ -0,0 +1,123 @@
+package links2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Fingerprint identifies the formatted text of a document, as returned by
+// PageText. Equal documents have equal fingerprints.
+type Fingerprint struct {
+	Sum   [sha256.Size]byte
+	lines []string // lines are kept for DiffAgainst.
+}
+
+// String returns the hex encoded Sum.
+func (f Fingerprint) String() string { return hex.EncodeToString(f.Sum[:]) }
+
+// Fingerprint returns the Fingerprint of the current document.
+func (b *Browser) Fingerprint() (Fingerprint, error) {
+	return b.FingerprintContext(context.Background())
+}
+
+// FingerprintContext is like Fingerprint but bounds waits by ctx.
+func (b *Browser) FingerprintContext(ctx context.Context) (Fingerprint, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	doc, err := b.formattedDocument(ctx)
+	if err != nil {
+		return Fingerprint{}, err
+	}
+	return fingerprint(doc), nil
+}
+
+func fingerprint(doc string) Fingerprint {
+	return Fingerprint{Sum: sha256.Sum256([]byte(doc)), lines: documentLines(doc)}
+}
+
+// LineChange is a line removed from or added to a document.
+type LineChange struct {
+	Added bool   // Added is set for added lines and unset for removed ones.
+	Line  int    // Line is the 0-based line in the new document if added, or the old one.
+	Text  string // Text is the line with trailing spaces trimmed.
+}
+
+// DiffAgainst returns the lines of the current document which changed since
+// prev was taken, as removed and added lines in document order. It returns
+// no changes if the documents are equal.
+func (b *Browser) DiffAgainst(prev Fingerprint) ([]LineChange, error) {
+	return b.DiffAgainstContext(context.Background(), prev)
+}
+
+// DiffAgainstContext is like DiffAgainst but bounds waits by ctx.
+func (b *Browser) DiffAgainstContext(ctx context.Context, prev Fingerprint) ([]LineChange, error) {
+	cur, err := b.FingerprintContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cur.Sum == prev.Sum {
+		return nil, nil
+	}
+	return diffLines(prev.lines, cur.lines), nil
+}
+
+// maxDiffCells bounds the size of the table diffLines computes the longest
+// common subsequence with, about 16MB.
+const maxDiffCells = 1 << 21
+
+// diffLines returns the changes turning a into b, from a longest common
+// subsequence of their lines. If the changed parts of a and b are too long
+// for the subsequence table, all of their lines are changed instead.
+func diffLines(a, b []string) []LineChange {
+	// Common leading and trailing lines are skipped to keep the table small.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	a, b = a[pre:len(a)-suf], b[pre:len(b)-suf]
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		changes := make([]LineChange, 0, len(a)+len(b))
+		for i, line := range a {
+			changes = append(changes, LineChange{Line: pre + i, Text: line})
+		}
+		for j, line := range b {
+			changes = append(changes, LineChange{Added: true, Line: pre + j, Text: line})
+		}
+		return changes
+	}
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var changes []LineChange
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			changes = append(changes, LineChange{Line: pre + i, Text: a[i]})
+			i++
+		default:
+			changes = append(changes, LineChange{Added: true, Line: pre + j, Text: b[j]})
+			j++
+		}
+	}
+	return changes
+}
@@ -0,0 +1,53 @@
+package links2
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	del := func(line int, text string) LineChange { return LineChange{Line: line, Text: text} }
+	add := func(line int, text string) LineChange { return LineChange{Added: true, Line: line, Text: text} }
+	tests := []struct {
+		a, b string
+		want []LineChange
+	}{
+		{"a b c", "a b c", nil},
+		{"", "a", []LineChange{add(0, "a")}},
+		{"a", "", []LineChange{del(0, "a")}},
+		{"a b c", "a x c", []LineChange{del(1, "b"), add(1, "x")}},
+		{"a b c", "a b c d", []LineChange{add(3, "d")}},
+		{"a b c", "b c", []LineChange{del(0, "a")}},
+		{"a b c d", "a c b d", []LineChange{del(1, "b"), add(2, "b")}},
+		{"x a x b x", "x b x", []LineChange{del(1, "a"), del(2, "x")}},
+	}
+	for _, tc := range tests {
+		got := diffLines(strings.Fields(tc.a), strings.Fields(tc.b))
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("diffLines(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestDiffLinesLarge(t *testing.T) {
+	const n = 10000
+	a := make([]string, n)
+	b := make([]string, n)
+	for i := range a {
+		a[i] = fmt.Sprint("old ", i)
+		b[i] = fmt.Sprint("new ", i)
+	}
+	a[0], b[0] = "same", "same"
+	changes := diffLines(a, b)
+	if len(changes) != 2*(n-1) {
+		t.Fatalf("diffLines: %d changes, want %d", len(changes), 2*(n-1))
+	}
+	if c := changes[0]; c.Added || c.Line != 1 || c.Text != "old 1" {
+		t.Errorf("first change = %+v", c)
+	}
+	if c := changes[len(changes)-1]; !c.Added || c.Line != n-1 || c.Text != fmt.Sprint("new ", n-1) {
+		t.Errorf("last change = %+v", c)
+	}
+}
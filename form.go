@@ -0,0 +1,204 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Form fields are links2 links, so they are focused with the link selection
+// keys. Text is typed directly into a focused text field, Enter toggles a
+// focused checkbox or radio button and opens the option menu of a focused
+// select field.
+
+// FocusNextField moves the focus to the next link or form field.
+func (b *Browser) FocusNextField() error { return b.action(ActionNextLink) }
+
+// FocusPrevField moves the focus to the previous link or form field.
+func (b *Browser) FocusPrevField() error { return b.action(ActionPrevLink) }
+
+// TypeText replaces the contents of the focused text field with s.
+func (b *Browser) TypeText(s string) error {
+	if err := checkInput(s); err != nil {
+		return err
+	}
+	// ^U clears the field.
+	return b.send("\025" + s)
+}
+
+// SetFile sets the focused file upload field to the local file at path.
+// Links2 draws file fields as text fields taking the path of the file, which
+// is read when the form is submitted.
+func (b *Browser) SetFile(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return fmt.Errorf("upload %s: not a regular file", path)
+	}
+	return b.TypeText(path)
+}
+
+// ToggleField toggles the focused checkbox or selects the focused radio button.
+func (b *Browser) ToggleField() error { return b.send("\n") }
+
+// SelectOption opens the option menu of the focused select field and picks the
+// option with the given label.
+func (b *Browser) SelectOption(label string) error {
+	return b.SelectOptionContext(context.Background(), label)
+}
+
+// SelectOptionContext is like SelectOption but bounds waits by ctx.
+func (b *Browser) SelectOptionContext(ctx context.Context, label string) error {
+	_, done := b.begin(ctx)
+	defer done()
+	if err := b.sendIdle("\n"); err != nil {
+		return err
+	}
+	raw, err := b.drain()
+	if err != nil {
+		return err
+	}
+	b.s = stateMenu
+	b.menuName = menuSelect
+	options := menuItems(raw)
+	if len(options) == 0 {
+		b.closeMenu()
+		return fmt.Errorf("focused field is not a select field")
+	}
+	for i, option := range options {
+		if option == label {
+			// The option menu opens with the current option selected,
+			// so move to the top before moving down to the option.
+			err := b.press(ActionFirstItem)
+			for ; err == nil && i > 0; i-- {
+				err = b.press(ActionNextItem)
+			}
+			if err == nil {
+				err = b.press(ActionSelect)
+			}
+			if err != nil {
+				b.closeMenu()
+				return err
+			}
+			b.s = stateIdle
+			b.menuName = ""
+			return nil
+		}
+	}
+	b.closeMenu()
+	return fmt.Errorf("select field has no option %q", label)
+}
+
+// menuItems returns the items of a popup menu drawn by raw terminal output.
+func menuItems(raw string) []string { return dialogLines(raw) }
+
+// SubmitForm submits the form of the focused text field or submit button and
+// waits for the resulting page to load.
+func (b *Browser) SubmitForm() (NavigateResult, error) {
+	return b.SubmitFormContext(context.Background())
+}
+
+// SubmitFormContext is like SubmitForm but bounds waits by ctx.
+func (b *Browser) SubmitFormContext(ctx context.Context) (NavigateResult, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	if err := b.closeMenu(); err != nil {
+		return NavigateResult{}, err
+	}
+	res := NavigateResult{Start: time.Now()}
+	err := b.expectLoad("\n\033", &res) // Enter, Esc
+	res.End = time.Now()
+	if err != nil {
+		return res, fmt.Errorf("submit form: %w", err)
+	}
+	return res, nil
+}
+
+// fieldNamePattern matches the field name links2 shows in the status bar for
+// a focused form field, e.g. "Text field, name q".
+var fieldNamePattern = regexp.MustCompile(`\bname ([^,\s]+)`)
+
+// FillForm types values into the text fields with the given names, visiting
+// each link and field of the document from the top. Field names are read
+// from the status bar. The focus is left on the last field filled.
+func (b *Browser) FillForm(values map[string]string) error {
+	return b.FillFormContext(context.Background(), values)
+}
+
+// FillFormContext is like FillForm but bounds waits by ctx.
+func (b *Browser) FillFormContext(ctx context.Context, values map[string]string) error {
+	_, done := b.begin(ctx)
+	defer done()
+	for _, v := range values {
+		if err := checkInput(v); err != nil {
+			return err
+		}
+	}
+	if err := b.perform(ActionHome); err != nil {
+		return err
+	}
+	filled := make(map[string]bool, len(values))
+	var last string
+	for i := 0; i < maxLinks && len(filled) < len(values); i++ {
+		if _, err := b.drain(); err != nil {
+			return err
+		}
+		status := b.statusBar()
+		row, text := b.scr.highlight(1)
+		focus := fmt.Sprint(row, text, status)
+		if focus == last {
+			break // The focus didn't move.
+		}
+		last = focus
+		if m := fieldNamePattern.FindStringSubmatch(status); m != nil {
+			if v, ok := values[m[1]]; ok && !filled[m[1]] {
+				b.c.Send("\025" + v) // ^U
+				filled[m[1]] = true
+				if len(filled) == len(values) {
+					break
+				}
+			}
+		}
+		if err := b.press(ActionNextLink); err != nil {
+			return err
+		}
+	}
+	if len(filled) < len(values) {
+		var missing []string
+		for name := range values {
+			if !filled[name] {
+				missing = append(missing, name)
+			}
+		}
+		sort.Strings(missing)
+		return fmt.Errorf("fill form: no fields named %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// SubmitFormValues fills the named fields like FillForm, then submits the
+// form of the last field filled and waits for the resulting page to load.
+func (b *Browser) SubmitFormValues(values map[string]string) (NavigateResult, error) {
+	return b.SubmitFormValuesContext(context.Background(), values)
+}
+
+// SubmitFormValuesContext is like SubmitFormValues but bounds waits by ctx.
+func (b *Browser) SubmitFormValuesContext(ctx context.Context, values map[string]string) (NavigateResult, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.FillFormContext(ctx, values); err != nil {
+		return NavigateResult{}, err
+	}
+	return b.SubmitFormContext(ctx)
+}
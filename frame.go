@@ -0,0 +1,112 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxFrames bounds the number of frames enumerated by Frames.
+const maxFrames = 64
+
+// FrameInfo describes a frame of the current frameset document.
+type FrameInfo struct {
+	Index int // Index is the position of the frame in the Tab order.
+	URL   string
+	Title string
+}
+
+// Frames returns the frames of the current document, or a single frame for a
+// document without frames.
+//
+// Frames are enumerated by moving to each frame in turn with Tab starting
+// from the selected frame, which becomes frame 0. Enumeration stops when the
+// Info dialog shows the first frame again.
+func (b *Browser) Frames() ([]FrameInfo, error) {
+	return b.FramesContext(context.Background())
+}
+
+// FramesContext is like Frames but bounds waits by ctx.
+func (b *Browser) FramesContext(ctx context.Context) ([]FrameInfo, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	var frames []FrameInfo
+	for len(frames) < maxFrames {
+		info, err := b.DocumentInfoContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(frames) > 0 && info.URL == frames[0].URL && info.Title == frames[0].Title {
+			break
+		}
+		frames = append(frames, FrameInfo{Index: len(frames), URL: info.URL, Title: info.Title})
+		if err := b.perform(ActionNextFrame); err != nil {
+			return nil, err
+		}
+	}
+	b.frames = frameState{url: b.lastURL, n: len(frames)}
+	return frames, nil
+}
+
+// EnterFrame shows frame i, as numbered by the last call to Frames, at full
+// screen (View→Frame at full-screen) so it can be used like a document.
+func (b *Browser) EnterFrame(i int) error {
+	return b.EnterFrameContext(context.Background(), i)
+}
+
+// EnterFrameContext is like EnterFrame but bounds waits by ctx.
+func (b *Browser) EnterFrameContext(ctx context.Context, i int) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	f := &b.frames
+	if f.n == 0 || f.url != b.lastURL {
+		return fmt.Errorf("enter frame: frames not enumerated; call Frames first")
+	}
+	if f.entered {
+		return fmt.Errorf("enter frame: already in a frame")
+	}
+	if i < 0 || i >= f.n {
+		return fmt.Errorf("enter frame: no frame %d of %d", i, f.n)
+	}
+	for ; f.cur != i; f.cur = (f.cur + 1) % f.n {
+		if err := b.perform(ActionNextFrame); err != nil {
+			return err
+		}
+	}
+	if err := b.OpenMenuContext(ctx, "View", "Frame at full-screen"); err != nil {
+		return err
+	}
+	b.c.Send("\033") // Esc signals the load finished as in Navigate.
+	var res NavigateResult
+	if err := b.expectLoaded(&res); err != nil {
+		return err
+	}
+	f.entered = true
+	return nil
+}
+
+// LeaveFrame goes back from the frame shown by EnterFrame to the frameset.
+func (b *Browser) LeaveFrame() error {
+	return b.LeaveFrameContext(context.Background())
+}
+
+// LeaveFrameContext is like LeaveFrame but bounds waits by ctx.
+func (b *Browser) LeaveFrameContext(ctx context.Context) error {
+	_, done := b.begin(ctx)
+	defer done()
+	if !b.frames.entered {
+		return fmt.Errorf("leave frame: not in a frame")
+	}
+	if err := b.sendLoad(ActionBack); err != nil {
+		return err
+	}
+	b.frames.entered = false
+	return nil
+}
+
+// frameState tracks the frame selection of the document at url.
+type frameState struct {
+	url     string
+	n       int  // n is the number of frames found by Frames.
+	cur     int  // cur is the selected frame.
+	entered bool // entered is set while a frame is shown by EnterFrame.
+}
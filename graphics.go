@@ -0,0 +1,109 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// defaultFramebuffer is the framebuffer device used by the links2 fb driver
+// unless FRAMEBUFFER is set.
+const defaultFramebuffer = "/dev/fb0"
+
+// WithGraphics runs links2 in graphics mode (-g) with the given graphics
+// driver, e.g. "fb" or "x". An empty driver lets links2 choose.
+//
+// In graphics mode links2 draws no text to the terminal, so operations which
+// match the screen don't work; use Screenshot to inspect the render.
+func WithGraphics(driver string) Option {
+	return func(o *options) error {
+		o.args = append(o.args, "-g")
+		if driver != "" {
+			o.args = append(o.args, "-driver", driver)
+		}
+		o.graphics = driver
+		return nil
+	}
+}
+
+// Screenshot captures the image links2 rendered in graphics mode.
+// Only the framebuffer driver ("fb") is supported; its device is read
+// directly, so run links2 on a framebuffer nothing else draws to.
+func (b *Browser) Screenshot() (image.Image, error) {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.s == stateUndefined {
+		return nil, ErrNotStarted
+	}
+	if b.opts.graphics != "fb" {
+		return nil, fmt.Errorf("screenshot: graphics driver %q: %w", b.opts.graphics, errors.ErrUnsupported)
+	}
+	dev := os.Getenv("FRAMEBUFFER")
+	if dev == "" {
+		dev = defaultFramebuffer
+	}
+	return readFramebuffer(dev)
+}
+
+// readFramebuffer reads the visible image of the framebuffer device dev,
+// taking its geometry from sysfs.
+func readFramebuffer(dev string) (image.Image, error) {
+	sys := filepath.Join("/sys/class/graphics", filepath.Base(dev))
+	size, err := os.ReadFile(filepath.Join(sys, "virtual_size"))
+	if err != nil {
+		return nil, err
+	}
+	ws, hs, ok := strings.Cut(strings.TrimSpace(string(size)), ",")
+	if !ok {
+		return nil, fmt.Errorf("framebuffer %s: malformed size: %q", dev, size)
+	}
+	w, err1 := strconv.Atoi(ws)
+	h, err2 := strconv.Atoi(hs)
+	bpp, err3 := readSysInt(filepath.Join(sys, "bits_per_pixel"))
+	stride, err4 := readSysInt(filepath.Join(sys, "stride"))
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return nil, fmt.Errorf("framebuffer %s: %w", dev, err)
+	}
+	buf, err := os.ReadFile(dev)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < stride*h {
+		return nil, fmt.Errorf("framebuffer %s: short read", dev)
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		row := buf[y*stride:]
+		for x := 0; x < w; x++ {
+			switch bpp {
+			case 32: // BGRX
+				p := row[4*x:]
+				img.SetRGBA(x, y, color.RGBA{p[2], p[1], p[0], 0xff})
+			case 24: // BGR
+				p := row[3*x:]
+				img.SetRGBA(x, y, color.RGBA{p[2], p[1], p[0], 0xff})
+			case 16: // RGB565
+				v := uint16(row[2*x]) | uint16(row[2*x+1])<<8
+				r, g, b := uint8(v>>11)<<3, uint8(v>>5&0x3f)<<2, uint8(v&0x1f)<<3
+				img.SetRGBA(x, y, color.RGBA{r, g, b, 0xff})
+			default:
+				return nil, fmt.Errorf("framebuffer %s: unsupported depth: %d bits", dev, bpp)
+			}
+		}
+	}
+	return img, nil
+}
+
+func readSysInt(path string) (int, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(buf)))
+}
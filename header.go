@@ -0,0 +1,132 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+const headerDialog = "Header info \033[0;7m"
+
+// maxHeaderPages bounds how far HTTPHeader scrolls a long header view.
+const maxHeaderPages = 16
+
+// HTTPHeader is the HTTP response header of the current document as shown by
+// the Header info dialog (|).
+type HTTPHeader struct {
+	Proto      string // Proto is the protocol, e.g. "HTTP/1.1".
+	Status     string // Status is the status line less the protocol, e.g. "200 OK".
+	StatusCode int
+	Header     http.Header
+}
+
+// HTTPHeader opens the Header info dialog and parses the response header.
+// Header views which do not fit on one screen are scrolled until no new lines
+// appear or links2 doesn't redraw.
+func (b *Browser) HTTPHeader() (HTTPHeader, error) {
+	return b.HTTPHeaderContext(context.Background())
+}
+
+// HTTPHeaderContext is like HTTPHeader but bounds waits by ctx.
+func (b *Browser) HTTPHeaderContext(ctx context.Context) (HTTPHeader, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	defer b.closeMenu()
+	if err := b.perform(ActionHeader); err != nil {
+		return HTTPHeader{}, err
+	}
+	b.s = stateMenu
+	b.menuName = menuHeader
+	if _, err := b.expectString(headerDialog); err != nil {
+		return HTTPHeader{}, b.keymapError(ActionHeader, err)
+	}
+	var lines []string
+	for i := 0; i < maxHeaderPages; i++ {
+		raw, err := b.expectString(okButton)
+		if i > 0 && errors.Is(err, ErrTimeout) {
+			// Links2 needn't redraw for a PgDn which doesn't scroll.
+			break
+		}
+		if err != nil {
+			return HTTPHeader{}, err
+		}
+		page := dialogLines(raw)
+		if len(page) > 0 && page[len(page)-1] == b.tr(okButton) {
+			page = page[:len(page)-1]
+		}
+		n := len(lines)
+		lines = mergePage(lines, page)
+		if len(lines) == n {
+			break
+		}
+		if err := b.press(ActionScrollDown); err != nil {
+			return HTTPHeader{}, err
+		}
+	}
+	return parseHTTPHeader(lines)
+}
+
+// mergePage appends the lines of page to lines, skipping any lines at the
+// start of page which overlap with the end of lines.
+func mergePage(lines, page []string) []string {
+	for overlap := min(len(lines), len(page)); overlap > 0; overlap-- {
+		if equalLines(lines[len(lines)-overlap:], page[:overlap]) {
+			return append(lines, page[overlap:]...)
+		}
+	}
+	return append(lines, page...)
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseHTTPHeader parses a status line followed by header fields.
+// Lines which are not fields continue the previous field value.
+func parseHTTPHeader(lines []string) (HTTPHeader, error) {
+	if len(lines) == 0 {
+		return HTTPHeader{}, fmt.Errorf("parse http header: empty header")
+	}
+	proto, status, ok := strings.Cut(lines[0], " ")
+	if !ok || !strings.HasPrefix(proto, "HTTP/") {
+		return HTTPHeader{}, fmt.Errorf("parse http header: malformed status line: %q", lines[0])
+	}
+	code, _, _ := strings.Cut(status, " ")
+	statusCode, err := strconv.Atoi(code)
+	if err != nil {
+		return HTTPHeader{}, fmt.Errorf("parse http header: malformed status code: %q", code)
+	}
+	h := HTTPHeader{
+		Proto:      proto,
+		Status:     status,
+		StatusCode: statusCode,
+		Header:     make(http.Header),
+	}
+	var lastKey string
+	for _, line := range lines[1:] {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.ContainsAny(key, " \t") {
+			if lastKey == "" {
+				return HTTPHeader{}, fmt.Errorf("parse http header: malformed field: %q", line)
+			}
+			values := h.Header[lastKey]
+			values[len(values)-1] += line
+			continue
+		}
+		lastKey = textproto.CanonicalMIMEHeaderKey(key)
+		h.Header.Add(lastKey, strings.TrimSpace(value))
+	}
+	return h, nil
+}
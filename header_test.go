@@ -0,0 +1,92 @@
+package links2
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHTTPHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  HTTPHeader
+	}{{
+		name:  "status only",
+		lines: []string{"HTTP/1.1 204 No Content"},
+		want:  HTTPHeader{Proto: "HTTP/1.1", Status: "204 No Content", StatusCode: 204, Header: http.Header{}},
+	}, {
+		name: "fields",
+		lines: []string{
+			"HTTP/1.0 200 OK",
+			"content-type: text/html; charset=UTF-8",
+			"Set-Cookie: a=1",
+			"Set-Cookie: b=2",
+			"Date:Mon, 01 Jan 2024 00:00:00 GMT",
+		},
+		want: HTTPHeader{Proto: "HTTP/1.0", Status: "200 OK", StatusCode: 200, Header: http.Header{
+			"Content-Type": {"text/html; charset=UTF-8"},
+			"Set-Cookie":   {"a=1", "b=2"},
+			"Date":         {"Mon, 01 Jan 2024 00:00:00 GMT"},
+		}},
+	}, {
+		name: "wrapped value",
+		lines: []string{
+			"HTTP/1.1 301 Moved Permanently",
+			"Location: http://example.com/a/very/long/",
+			"path/that/wrapped",
+			"X-Note: a b",
+		},
+		want: HTTPHeader{Proto: "HTTP/1.1", Status: "301 Moved Permanently", StatusCode: 301, Header: http.Header{
+			"Location": {"http://example.com/a/very/long/path/that/wrapped"},
+			"X-Note":   {"a b"},
+		}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseHTTPHeader(tc.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseHTTPHeader = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseHTTPHeaderError(t *testing.T) {
+	for _, lines := range [][]string{
+		nil,
+		{"200 OK"},
+		{"HTTP/1.1"},
+		{"HTTP/1.1 OK"},
+		{"HTTP/1.1 200 OK", "no field here"},
+	} {
+		if h, err := parseHTTPHeader(lines); err == nil {
+			t.Errorf("parseHTTPHeader(%q) = %+v", lines, h)
+		}
+	}
+}
+
+func TestMergePage(t *testing.T) {
+	tests := []struct {
+		lines, page, want string
+	}{
+		{"", "a b", "a b"},
+		{"a b", "", "a b"},
+		{"a b c", "d e", "a b c d e"},
+		{"a b c", "c d", "a b c d"},
+		{"a b c", "b c d", "a b c d"},
+		{"a b c", "a b c", "a b c"},
+		{"a b a b", "a b a b x", "a b a b x"},
+		{"x a", "a a y", "x a a y"},
+	}
+	for _, tc := range tests {
+		got := mergePage(strings.Fields(tc.lines), strings.Fields(tc.page))
+		if strings.Join(got, " ") != tc.want {
+			t.Errorf("mergePage(%q, %q) = %q, want %q", tc.lines, tc.page, got, tc.want)
+		}
+	}
+}
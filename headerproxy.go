@@ -0,0 +1,122 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+)
+
+// WithHeaders adds the header fields of h to the HTTP requests of links2,
+// which has no setting for extra header fields. Requests are sent through
+// an HTTP proxy run by the package on the loopback interface, which adds the
+// fields; it replaces any http proxy given by WithProxy.
+//
+// HTTPS requests pass through the proxy as tunnels which it can't read, so
+// they are sent without the fields.
+func WithHeaders(h http.Header) Option {
+	return func(o *options) error {
+		o.headers = h.Clone()
+		return nil
+	}
+}
+
+// SetHeaders replaces the header fields added to subsequent requests. The
+// browser must have been opened with WithHeaders.
+func (b *Browser) SetHeaders(h http.Header) error {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.headerProxy == nil {
+		return errors.New("set headers: browser not opened with WithHeaders")
+	}
+	b.headerProxy.setHeader(h)
+	return nil
+}
+
+// headerProxy is a forward HTTP proxy adding header fields to requests.
+type headerProxy struct {
+	ln  net.Listener
+	srv *http.Server
+
+	mu     sync.Mutex
+	header http.Header
+}
+
+// startHeaderProxy starts a headerProxy adding h on a loopback port.
+func startHeaderProxy(h http.Header) (*headerProxy, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &headerProxy{ln: ln, header: h}
+	rp := &httputil.ReverseProxy{
+		// Requests to a proxy have absolute URLs, which are kept.
+		Rewrite: func(r *httputil.ProxyRequest) {
+			for k, v := range p.headers() {
+				r.Out.Header[k] = v
+			}
+		},
+		Transport: &http.Transport{},
+	}
+	p.srv = &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodConnect {
+				tunnel(w, r)
+				return
+			}
+			rp.ServeHTTP(w, r)
+		}),
+		ReadHeaderTimeout: time.Minute,
+	}
+	go p.srv.Serve(ln)
+	return p, nil
+}
+
+func (p *headerProxy) addr() string { return p.ln.Addr().String() }
+
+func (p *headerProxy) headers() http.Header {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.header
+}
+
+func (p *headerProxy) setHeader(h http.Header) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.header = h.Clone()
+}
+
+func (p *headerProxy) close() error { return p.srv.Close() }
+
+// tunnel connects the client of a CONNECT request to the requested host.
+func tunnel(w http.ResponseWriter, r *http.Request) {
+	dst, err := net.DialTimeout("tcp", r.Host, 30*time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		dst.Close()
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	src, rw, err := hj.Hijack()
+	if err != nil {
+		dst.Close()
+		return
+	}
+	fmt.Fprint(src, "HTTP/1.1 200 Connection established\r\n\r\n")
+	go func() {
+		defer dst.Close()
+		// Bytes the client sent after the request are buffered in rw.
+		io.Copy(dst, rw)
+	}()
+	defer src.Close()
+	io.Copy(src, dst)
+}
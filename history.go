@@ -0,0 +1,93 @@
+package links2
+
+import (
+	"context"
+	"strings"
+)
+
+// HistoryEntry is a document in the back history listed in the History menu.
+type HistoryEntry struct {
+	URL string
+}
+
+// History returns the back history as listed in the File→History menu,
+// most recent first.
+func (b *Browser) History() ([]HistoryEntry, error) {
+	return b.HistoryContext(context.Background())
+}
+
+// HistoryContext is like History but bounds waits by ctx.
+func (b *Browser) HistoryContext(ctx context.Context) ([]HistoryEntry, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	defer b.closeMenu()
+	if err := b.OpenMenuContext(ctx, "File", "History"); err != nil {
+		return nil, err
+	}
+	b.menuName = menuHistory
+	raw, err := b.drain()
+	if err != nil {
+		return nil, err
+	}
+	var entries []HistoryEntry
+	for _, line := range menuItems(raw) {
+		if strings.Contains(line, ":") {
+			entries = append(entries, HistoryEntry{URL: line})
+		}
+	}
+	return entries, nil
+}
+
+// BackLink goes back to the previous document in the history.
+// It returns false if the history was empty so there was nowhere to go back to.
+func (b *Browser) BackLink() (bool, error) {
+	return b.BackLinkContext(context.Background())
+}
+
+// BackLinkContext is like BackLink but bounds waits by ctx.
+func (b *Browser) BackLinkContext(ctx context.Context) (bool, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	entries, err := b.HistoryContext(ctx)
+	if err != nil || len(entries) == 0 {
+		return false, err
+	}
+	return true, b.sendLoad(ActionBack)
+}
+
+// Forward goes forward to the next document, undoing BackLink.
+// It returns false if the current document did not change.
+func (b *Browser) Forward() (bool, error) {
+	return b.ForwardContext(context.Background())
+}
+
+// ForwardContext is like Forward but bounds waits by ctx.
+func (b *Browser) ForwardContext(ctx context.Context) (bool, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	before, err := b.DocumentInfoContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	if err := b.sendLoad(ActionForward); err != nil {
+		return false, err
+	}
+	after, err := b.DocumentInfoContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	return after.URL != before.URL, nil
+}
+
+// sendLoad sends keys which may load a document and waits for the load to finish.
+func (b *Browser) sendLoad(a Action) error {
+	keys, err := b.keys(a)
+	if err != nil {
+		return err
+	}
+	if err := b.sendIdle(keys + "\033"); err != nil { // Esc
+		return err
+	}
+	var res NavigateResult
+	return b.expectLoaded(&res)
+}
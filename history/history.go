@@ -0,0 +1,137 @@
+// Package history reads and writes the history files of links2.
+//
+// Links2 keeps the history of the Go to URL dialog in links.his, one URL per
+// line, oldest first. Browsers which keep a global history of visited
+// documents, such as ELinks, store one entry per line as tab separated
+// fields:
+//
+//	title	url	last-visit
+//
+// where last-visit is a Unix time.
+package history
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GotoFile is the name of the Go to URL history file in the links2 directory.
+const GotoFile = "links.his"
+
+// Entry is an entry of a global history.
+type Entry struct {
+	URL     string
+	Title   string
+	Visited time.Time // Visited is the time of the last visit.
+}
+
+// DefaultGotoPath returns the path of the Go to URL history file of the
+// current user.
+func DefaultGotoPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".links", GotoFile), nil
+}
+
+// DefaultGlobalPath returns the path of the ELinks global history file of
+// the current user.
+func DefaultGlobalPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".elinks", "globhist"), nil
+}
+
+// ReadGoto parses a Go to URL history file, oldest URL first.
+func ReadGoto(r io.Reader) ([]string, error) {
+	var urls []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if s := strings.TrimSpace(sc.Text()); s != "" {
+			urls = append(urls, s)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// WriteGoto writes urls, oldest first, in the Go to URL history format.
+func WriteGoto(w io.Writer, urls []string) error {
+	bw := bufio.NewWriter(w)
+	for _, u := range urls {
+		if strings.ContainsAny(u, "\r\n") {
+			return fmt.Errorf("history: url %q contains a newline", u)
+		}
+		fmt.Fprintln(bw, u)
+	}
+	return bw.Flush()
+}
+
+// LoadGoto reads the Go to URL history file at path.
+func LoadGoto(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadGoto(f)
+}
+
+// SaveGoto writes urls to the Go to URL history file at path, replacing it.
+func SaveGoto(path string, urls []string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteGoto(f, urls); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadGlobal parses a global history file.
+func ReadGlobal(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		s := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		f := strings.Split(s, "\t")
+		if len(f) != 3 {
+			return nil, fmt.Errorf("history: line %d: want 3 fields, got %d", line, len(f))
+		}
+		visited, err := strconv.ParseInt(f[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("history: line %d: invalid last visit: %q", line, f[2])
+		}
+		entries = append(entries, Entry{Title: f[0], URL: f[1], Visited: time.Unix(visited, 0)})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// LoadGlobal reads the global history file at path.
+func LoadGlobal(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadGlobal(f)
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadGoto(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"http://a.example/\n", []string{"http://a.example/"}},
+		{"http://a.example/\n\n  http://b.example/  \r\nfile:///tmp/x", []string{"http://a.example/", "http://b.example/", "file:///tmp/x"}},
+	}
+	for _, tc := range tests {
+		got, err := ReadGoto(strings.NewReader(tc.in))
+		if err != nil {
+			t.Errorf("ReadGoto(%q): %v", tc.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ReadGoto(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestWriteGoto(t *testing.T) {
+	urls := []string{"http://a.example/", "http://b.example/?q=a b"}
+	var buf bytes.Buffer
+	if err := WriteGoto(&buf, urls); err != nil {
+		t.Fatal(err)
+	}
+	if want := "http://a.example/\nhttp://b.example/?q=a b\n"; buf.String() != want {
+		t.Errorf("WriteGoto = %q, want %q", buf.String(), want)
+	}
+	got, err := ReadGoto(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, urls) {
+		t.Errorf("round trip = %q, want %q", got, urls)
+	}
+	for _, u := range []string{"a\nb", "a\rb"} {
+		if err := WriteGoto(&bytes.Buffer{}, []string{u}); err == nil {
+			t.Errorf("WriteGoto(%q) succeeded", u)
+		}
+	}
+}
+
+func TestSaveLoadGoto(t *testing.T) {
+	path := filepath.Join(t.TempDir(), GotoFile)
+	urls := []string{"http://a.example/", "http://b.example/"}
+	if err := SaveGoto(path, urls); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadGoto(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, urls) {
+		t.Errorf("LoadGoto = %q, want %q", got, urls)
+	}
+}
+
+func TestReadGlobal(t *testing.T) {
+	in := "Example\thttp://example.com/\t1700000000\n\n" +
+		"\thttp://untitled.example/\t1700000001\r\n" +
+		"Tabs\tfile:///tmp/a.html\t0\n"
+	got, err := ReadGlobal(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{
+		{Title: "Example", URL: "http://example.com/", Visited: time.Unix(1700000000, 0)},
+		{URL: "http://untitled.example/", Visited: time.Unix(1700000001, 0)},
+		{Title: "Tabs", URL: "file:///tmp/a.html", Visited: time.Unix(0, 0)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadGlobal = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadGlobalError(t *testing.T) {
+	for _, in := range []string{
+		"Example\thttp://example.com/\n",
+		"Example\thttp://example.com/\t1\textra\n",
+		"Example\thttp://example.com/\tyesterday\n",
+	} {
+		if e, err := ReadGlobal(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadGlobal(%q) = %+v", in, e)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Labels of the HTML options dialog.
+const (
+	labelTables        = "Display tables"
+	labelFrames        = "Display frames"
+	labelImageLinks    = "Display links to images"
+	labelNumberedLinks = "Number links"
+)
+
+// HTMLSettings are the rendering settings of the HTML options dialog.
+// Numbered links and plain tables make link enumeration and text extraction
+// more reliable.
+type HTMLSettings struct {
+	Tables        bool // Tables renders tables as laid out rather than linearized.
+	Frames        bool
+	ImageLinks    bool // ImageLinks shows links to images which have no alt text.
+	NumberedLinks bool // NumberedLinks prefixes each link with its number.
+	Margin        int  // Margin is the text margin in columns.
+}
+
+// SetHTMLSettings sets the default HTML options (Setup→HTML options), which
+// apply to documents loaded afterwards. Use SaveSettings to persist them.
+func (b *Browser) SetHTMLSettings(hs HTMLSettings) error {
+	return b.SetHTMLSettingsContext(context.Background(), hs)
+}
+
+// SetHTMLSettingsContext is like SetHTMLSettings but bounds waits by ctx.
+func (b *Browser) SetHTMLSettingsContext(ctx context.Context, hs HTMLSettings) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	return b.setHTMLSettings(ctx, hs, "Setup", "HTML options")
+}
+
+// SetDocumentHTMLSettings sets the HTML options of the current document
+// (View→HTML options) and redraws it.
+func (b *Browser) SetDocumentHTMLSettings(hs HTMLSettings) error {
+	return b.SetDocumentHTMLSettingsContext(context.Background(), hs)
+}
+
+// SetDocumentHTMLSettingsContext is like SetDocumentHTMLSettings but bounds waits by ctx.
+func (b *Browser) SetDocumentHTMLSettingsContext(ctx context.Context, hs HTMLSettings) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	return b.setHTMLSettings(ctx, hs, "View", "HTML options")
+}
+
+func (b *Browser) setHTMLSettings(ctx context.Context, hs HTMLSettings, path ...string) error {
+	if err := b.openDialog(ctx, path...); err != nil {
+		return err
+	}
+	return b.setControls(map[string]bool{
+		labelTables:        hs.Tables,
+		labelFrames:        hs.Frames,
+		labelImageLinks:    hs.ImageLinks,
+		labelNumberedLinks: hs.NumberedLinks,
+	}, strconv.Itoa(hs.Margin))
+}
+
+// SetLinkNumbers turns the numbering of links in the current document on or
+// off (the Number links HTML option), leaving the other options as they are.
+// Numbered links can be followed with FollowLinkNumber.
+func (b *Browser) SetLinkNumbers(on bool) error {
+	return b.SetLinkNumbersContext(context.Background(), on)
+}
+
+// SetLinkNumbersContext is like SetLinkNumbers but bounds waits by ctx.
+func (b *Browser) SetLinkNumbersContext(ctx context.Context, on bool) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.openDialog(ctx, "View", "HTML options"); err != nil {
+		return err
+	}
+	return b.setControls(map[string]bool{labelNumberedLinks: on})
+}
+
+// FollowLinkNumber follows link n (1-based) as numbered by SetLinkNumbers
+// and waits for the page to load. Typing the number selects the link.
+func (b *Browser) FollowLinkNumber(n int) error {
+	return b.FollowLinkNumberContext(context.Background(), n)
+}
+
+// FollowLinkNumberContext is like FollowLinkNumber but bounds waits by ctx.
+func (b *Browser) FollowLinkNumberContext(ctx context.Context, n int) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if n < 1 {
+		return fmt.Errorf("invalid link number: %d", n)
+	}
+	if err := b.sendIdle(strconv.Itoa(n) + "\n"); err != nil {
+		return err
+	}
+	if ok, err := b.interceptSelectedMailto(ctx); ok || err != nil {
+		return err
+	}
+	return b.sendLoad(ActionFollowLink)
+}
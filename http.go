@@ -0,0 +1,233 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const httpOptions = "HTTP options"
+
+// SetUserAgent sets the User-Agent sent by the running links2 (the fake
+// user agent of Setup→Network options→HTTP options).
+// Use SaveSettings to persist it.
+func (b *Browser) SetUserAgent(ua string) error {
+	return b.SetUserAgentContext(context.Background(), ua)
+}
+
+// SetUserAgentContext is like SetUserAgent but bounds waits by ctx.
+func (b *Browser) SetUserAgentContext(ctx context.Context, ua string) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if ua == "" {
+		return fmt.Errorf("empty user agent")
+	}
+	return b.setHTTPOptions(ctx, nil, "", ua)
+}
+
+// WithUserAgent sends ua as the User-Agent, by setting fake_useragent in
+// the links.cfg of WithConfig.
+func WithUserAgent(ua string) Option {
+	return withConfigOption("fake_useragent", ua)
+}
+
+// RefererPolicy is what links2 sends as the Referer header.
+// The values are those of the http_referer option of links.cfg.
+type RefererPolicy int
+
+const (
+	RefererNone           RefererPolicy = iota // RefererNone sends no Referer.
+	RefererSameURL                             // RefererSameURL sends the requested URL.
+	RefererFake                                // RefererFake sends a fixed fake referer.
+	RefererReal                                // RefererReal sends the linking page.
+	RefererRealSameServer                      // RefererRealSameServer sends the linking page to its own server only.
+)
+
+// refererLabels are the radio buttons of the HTTP options dialog.
+var refererLabels = [...]string{
+	RefererNone:           "No referer",
+	RefererSameURL:        "Send requested URL as referer",
+	RefererFake:           "Send fake referer",
+	RefererReal:           "Send real referer",
+	RefererRealSameServer: "Send real referer only to the same server",
+}
+
+func (p RefererPolicy) String() string {
+	if p < 0 || int(p) >= len(refererLabels) {
+		return fmt.Sprintf("RefererPolicy(%d)", int(p))
+	}
+	return refererLabels[p]
+}
+
+// SetRefererPolicy sets the Referer policy of the running links2, and for
+// RefererFake the fake referer to send. Use SaveSettings to persist it.
+func (b *Browser) SetRefererPolicy(p RefererPolicy, fake string) error {
+	return b.SetRefererPolicyContext(context.Background(), p, fake)
+}
+
+// SetRefererPolicyContext is like SetRefererPolicy but bounds waits by ctx.
+func (b *Browser) SetRefererPolicyContext(ctx context.Context, p RefererPolicy, fake string) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if p < 0 || int(p) >= len(refererLabels) {
+		return fmt.Errorf("invalid referer policy: %v", p)
+	}
+	if p != RefererFake {
+		fake = ""
+	}
+	return b.setHTTPOptions(ctx, map[string]bool{refererLabels[p]: true}, fake)
+}
+
+// WithRefererPolicy sets the Referer policy, and for RefererFake the fake
+// referer, in the links.cfg of WithConfig.
+func WithRefererPolicy(p RefererPolicy, fake string) Option {
+	return func(o *options) error {
+		if p < 0 || int(p) >= len(refererLabels) {
+			return fmt.Errorf("invalid referer policy: %v", p)
+		}
+		if err := withConfigOption("http_referer", strconv.Itoa(int(p)))(o); err != nil {
+			return err
+		}
+		if p == RefererFake {
+			return withConfigOption("fake_referer", fake)(o)
+		}
+		return nil
+	}
+}
+
+const labelAcceptLanguage = "Accept-Language"
+
+// SetAcceptLanguage sets the languages the running links2 asks for with the
+// Accept-Language header, e.g. "de-CH", "de;q=0.8". Use SaveSettings to
+// persist them.
+func (b *Browser) SetAcceptLanguage(tags ...string) error {
+	return b.SetAcceptLanguageContext(context.Background(), tags...)
+}
+
+// SetAcceptLanguageContext is like SetAcceptLanguage but bounds waits by ctx.
+func (b *Browser) SetAcceptLanguageContext(ctx context.Context, tags ...string) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	value, err := acceptLanguage(tags)
+	if err != nil {
+		return err
+	}
+	return b.setHTTPOptions(ctx, nil, "", "", value)
+}
+
+// AcceptLanguage returns the languages links2 sends in the Accept-Language
+// header, as shown by the HTTP options dialog.
+func (b *Browser) AcceptLanguage() ([]string, error) {
+	return b.AcceptLanguageContext(context.Background())
+}
+
+// AcceptLanguageContext is like AcceptLanguage but bounds waits by ctx.
+func (b *Browser) AcceptLanguageContext(ctx context.Context) ([]string, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	defer b.closeMenu()
+	if err := b.openHTTPOptions(ctx); err != nil {
+		return nil, err
+	}
+	value, ok := fieldValue(b.scr.lines(), b.tr(labelAcceptLanguage))
+	if !ok {
+		return nil, fmt.Errorf("http options: no field %q", labelAcceptLanguage)
+	}
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// WithAcceptLanguage sets the languages of the Accept-Language header in
+// the links.cfg of WithConfig.
+func WithAcceptLanguage(tags ...string) Option {
+	return func(o *options) error {
+		value, err := acceptLanguage(tags)
+		if err != nil {
+			return err
+		}
+		return withConfigOption("http_accept_language", value)(o)
+	}
+}
+
+// languageTag matches a language range with an optional quality value.
+var languageTag = regexp.MustCompile(`^(\*|[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*)(;q=[01](\.[0-9]{0,3})?)?$`)
+
+// acceptLanguage returns the Accept-Language value of tags.
+func acceptLanguage(tags []string) (string, error) {
+	if len(tags) == 0 {
+		return "", fmt.Errorf("no language tags")
+	}
+	for _, tag := range tags {
+		if !languageTag.MatchString(tag) {
+			return "", fmt.Errorf("invalid language tag: %q", tag)
+		}
+	}
+	return strings.Join(tags, ","), nil
+}
+
+// fieldValue returns the value of the text field drawn after label on the
+// same line, less the padding drawn for the empty part of the field.
+func fieldValue(lines []string, label string) (string, bool) {
+	for _, line := range lines {
+		_, value, ok := strings.Cut(line, label)
+		if !ok {
+			continue
+		}
+		value = strings.TrimPrefix(strings.TrimSpace(value), ":")
+		return strings.Trim(value, " _|"), true
+	}
+	return "", false
+}
+
+// setHTTPOptions sets the controls and text fields of the HTTP options
+// dialog as setControls does and accepts the Network options dialog it's
+// opened from. The text fields are the fake referer, fake user agent and
+// Accept-Language.
+func (b *Browser) setHTTPOptions(ctx context.Context, want map[string]bool, fields ...string) error {
+	if err := b.openHTTPOptions(ctx); err != nil {
+		return err
+	}
+	if err := b.setControls(want, fields...); err != nil {
+		return err
+	}
+	// Back in the Network options dialog.
+	b.s = stateMenu
+	if err := b.selectMenuItem(1, okButton, ActionNextControl); err != nil {
+		b.closeMenu()
+		return fmt.Errorf("http options: %w", err)
+	}
+	if err := b.press(ActionSelect); err != nil {
+		return err
+	}
+	b.s = stateIdle
+	b.menuName = ""
+	return nil
+}
+
+// openHTTPOptions opens the HTTP options dialog from Setup→Network options.
+func (b *Browser) openHTTPOptions(ctx context.Context) error {
+	if err := b.openDialog(ctx, "Setup", "Network options"); err != nil {
+		return err
+	}
+	if err := b.selectMenuItem(1, httpOptions, ActionNextControl); err != nil {
+		b.closeMenu()
+		return fmt.Errorf("http options: %w", err)
+	}
+	// Select opens the HTTP options.
+	if err := b.press(ActionSelect); err != nil {
+		return err
+	}
+	if _, err := b.expectString(okButton); err != nil {
+		b.closeMenu()
+		return err
+	}
+	_, err := b.drain()
+	return err
+}
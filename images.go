@@ -0,0 +1,110 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Images returns the images of the current document in document order as
+// links to them, with the alternative text of each image as the text of its
+// link. Images are parsed from the source and their URLs resolved against
+// the document URL.
+func (b *Browser) Images() ([]Link, error) {
+	return b.ImagesContext(context.Background())
+}
+
+// ImagesContext is like Images but bounds waits by ctx.
+func (b *Browser) ImagesContext(ctx context.Context) ([]Link, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	var src bytes.Buffer
+	if err := b.writeSaved(&src, func(path string) error { return b.saveSource(ctx, path) }); err != nil {
+		return nil, err
+	}
+	base, _ := url.Parse(b.lastURL)
+	return images(src.String(), base), nil
+}
+
+// images returns the img elements of an HTML document as links, resolved
+// against base if it's not nil.
+func images(src string, base *url.URL) []Link {
+	var links []Link
+	for _, tok := range tokenize(src) {
+		if tok.kind != startToken || tok.tag != "img" {
+			continue
+		}
+		ref := strings.TrimSpace(tok.attrs["src"])
+		if ref == "" {
+			continue
+		}
+		if u, err := url.Parse(ref); err == nil && base != nil {
+			ref = base.ResolveReference(u).String()
+		}
+		links = append(links, Link{Text: strings.Join(strings.Fields(tok.attrs["alt"]), " "), URL: ref, Index: len(links)})
+	}
+	return links
+}
+
+// DownloadImages downloads each image returned by Images to dir with
+// DownloadURL, naming the files after the image URLs. It returns the
+// downloads started, stopping at the first one which fails.
+func (b *Browser) DownloadImages(dir string) ([]*Download, error) {
+	return b.DownloadImagesContext(context.Background(), dir)
+}
+
+// DownloadImagesContext is like DownloadImages but bounds waits by ctx.
+func (b *Browser) DownloadImagesContext(ctx context.Context, dir string) ([]*Download, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	imgs, err := b.ImagesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.downloadAll(ctx, imgs, dir)
+}
+
+// downloadAll downloads the targets of links to dir, one file per URL.
+// Inline data: URLs are skipped.
+func (b *Browser) downloadAll(ctx context.Context, links []Link, dir string) ([]*Download, error) {
+	var (
+		downloads []*Download
+		names     = make(map[string]bool)
+		seen      = make(map[string]bool)
+	)
+	for _, l := range links {
+		if seen[l.URL] || strings.HasPrefix(l.URL, "data:") {
+			continue
+		}
+		seen[l.URL] = true
+		d, err := b.DownloadURLContext(ctx, l.URL, filepath.Join(dir, downloadName(l.URL, names)))
+		if err != nil {
+			return downloads, err
+		}
+		downloads = append(downloads, d)
+	}
+	return downloads, nil
+}
+
+// downloadName returns a file name for the document at rawURL which is not
+// in used, and adds it to used. Names are the last element of the URL path,
+// numbered if taken.
+func downloadName(rawURL string, used map[string]bool) string {
+	name := "download"
+	if u, err := url.Parse(rawURL); err == nil {
+		if base := path.Base(u.Path); base != "/" && base != "." && base != ".." {
+			name = sanitizeInput(base)
+		}
+	}
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s-%d%s", stem, i, ext)
+	}
+	used[name] = true
+	return name
+}
@@ -0,0 +1,141 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	infoDialog = "Info \033[0;7m"
+	okButton   = "[ OK ]"
+)
+
+// DocumentInfo describes the current document as shown by the Info dialog (=).
+type DocumentInfo struct {
+	URL          string
+	Title        string
+	Size         int64  // Size of the document in bytes or -1 if unknown.
+	Incomplete   bool   // Incomplete is set when the document was not fully loaded.
+	Encoding     string // Encoding is the codepage used to render the document.
+	LastModified string
+	Server       string
+	Cached       bool   // Cached is set when the document is in the links2 cache.
+	Link         string // Link is the target of the selected link, if any.
+}
+
+// DocumentInfo opens the Info dialog and parses the document info fields.
+func (b *Browser) DocumentInfo() (DocumentInfo, error) {
+	return b.DocumentInfoContext(context.Background())
+}
+
+// DocumentInfoContext is like DocumentInfo but bounds waits by ctx.
+func (b *Browser) DocumentInfoContext(ctx context.Context) (DocumentInfo, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	info, _, err := b.documentInfo()
+	return info, err
+}
+
+// CurrentURL returns the URL of the current document as reported by the Info
+// dialog, which reflects redirects and followed links.
+func (b *Browser) CurrentURL() (*url.URL, error) {
+	return b.CurrentURLContext(context.Background())
+}
+
+// CurrentURLContext is like CurrentURL but bounds waits by ctx.
+func (b *Browser) CurrentURLContext(ctx context.Context) (*url.URL, error) {
+	info, err := b.DocumentInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(info.URL)
+}
+
+// documentInfo opens the Info dialog and parses the document info fields.
+// It also returns the raw output read up to the dialog, which includes any
+// redraw caused by the preceding key presses.
+func (b *Browser) documentInfo() (info DocumentInfo, before string, err error) {
+	defer b.closeMenu()
+	if err := b.perform(ActionInfo); err != nil {
+		return DocumentInfo{}, "", err
+	}
+	b.s = stateMenu
+	b.menuName = menuInfo
+	before, err = b.expectString(infoDialog)
+	if err != nil {
+		return DocumentInfo{}, "", b.keymapError(ActionInfo, err)
+	}
+	before = strings.TrimSuffix(before, infoDialog)
+	raw, err := b.expectString(okButton)
+	if err != nil {
+		return DocumentInfo{}, "", err
+	}
+	info, err = parseDocumentInfo(dialogLines(raw), b.tr(okButton))
+	return info, before, err
+}
+
+// parseDocumentInfo parses the "Key: value" lines of the Info dialog.
+// Lines without a known key continue the previous value.
+// The OK button line, given by ok, is skipped.
+func parseDocumentInfo(lines []string, ok string) (DocumentInfo, error) {
+	info := DocumentInfo{Size: -1}
+	var last *string
+	for _, line := range lines {
+		if line == ok {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			if strings.Contains(line, "not in cache") {
+				continue
+			}
+			if last != nil {
+				if strings.ContainsRune(*last, ' ') {
+					*last += " "
+				}
+				*last += line
+			}
+			continue
+		}
+		last = nil
+		switch key {
+		case "URL":
+			info.URL, last = value, &info.URL
+		case "Title":
+			info.Title, last = value, &info.Title
+		case "Size":
+			size, incomplete, err := parseSize(value)
+			if err != nil {
+				return DocumentInfo{}, fmt.Errorf("parse document info: %w", err)
+			}
+			info.Size, info.Incomplete, info.Cached = size, incomplete, true
+		case "Codepage":
+			info.Encoding = value
+		case "Last modified":
+			info.LastModified = value
+		case "Server":
+			info.Server, last = value, &info.Server
+		case "Link":
+			info.Link, last = value, &info.Link
+		}
+	}
+	if info.URL == "" {
+		return DocumentInfo{}, fmt.Errorf("parse document info: missing URL")
+	}
+	return info, nil
+}
+
+// parseSize parses a size field like "1256 bytes, incomplete".
+func parseSize(s string) (size int64, incomplete bool, err error) {
+	s, rest, _ := strings.Cut(s, ",")
+	incomplete = strings.Contains(rest, "incomplete")
+	s = strings.TrimSuffix(strings.TrimSpace(s), " bytes")
+	size, err = strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid size: %q", s)
+	}
+	return size, incomplete, nil
+}
@@ -0,0 +1,84 @@
+package links2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDocumentInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  DocumentInfo
+	}{{
+		name: "full",
+		lines: []string{
+			"URL: http://example.com/",
+			"Title: Example Domain",
+			"Size: 1256 bytes",
+			"Codepage: utf-8",
+			"Last modified: Thu, 17 Oct 2019 07:18:26 GMT",
+			"Server: ECS (nyb/1D2E)",
+			"[ OK ]",
+		},
+		want: DocumentInfo{
+			URL:          "http://example.com/",
+			Title:        "Example Domain",
+			Size:         1256,
+			Encoding:     "utf-8",
+			LastModified: "Thu, 17 Oct 2019 07:18:26 GMT",
+			Server:       "ECS (nyb/1D2E)",
+			Cached:       true,
+		},
+	}, {
+		name:  "incomplete",
+		lines: []string{"URL: http://example.com/big", "Size: 4096 bytes, incomplete"},
+		want:  DocumentInfo{URL: "http://example.com/big", Size: 4096, Incomplete: true, Cached: true},
+	}, {
+		name:  "not in cache",
+		lines: []string{"URL: file:///tmp/a.html", "Document not in cache."},
+		want:  DocumentInfo{URL: "file:///tmp/a.html", Size: -1},
+	}, {
+		name: "wrapped",
+		lines: []string{
+			"URL: http://example.com/a/very/long/",
+			"path/to/page.html",
+			"Title: A title wrapped",
+			"over two lines",
+			"Link: http://example.com/",
+			"next",
+		},
+		want: DocumentInfo{
+			URL:   "http://example.com/a/very/long/path/to/page.html",
+			Title: "A title wrapped over two lines",
+			Size:  -1,
+			Link:  "http://example.com/next",
+		},
+	}, {
+		name:  "unknown keys",
+		lines: []string{"URL: http://example.com/", "Flavor: vanilla", "more"},
+		want:  DocumentInfo{URL: "http://example.com/", Size: -1},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseDocumentInfo(tc.lines, "[ OK ]")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseDocumentInfo = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseDocumentInfoError(t *testing.T) {
+	for _, lines := range [][]string{
+		{"Title: no URL"},
+		{"URL: http://example.com/", "Size: lots"},
+	} {
+		if info, err := parseDocumentInfo(lines, "[ OK ]"); err == nil {
+			t.Errorf("parseDocumentInfo(%q) = %+v", lines, info)
+		}
+	}
+}
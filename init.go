@@ -0,0 +1,82 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ajzaff/links2/config"
+)
+
+// welcomeScreen is the title of the welcome screen links2 shows on first run.
+const welcomeScreen = "Welcome"
+
+// maxFirstRunScreens bounds how many first-run screens Initialize dismisses.
+const maxFirstRunScreens = 3
+
+// WithInitialize runs links2 with a fresh home directory, as WithConfig
+// does, and calls Initialize once it's opened, so Open returns with links2
+// idle instead of racing its first-run screens.
+func WithInitialize() Option {
+	return func(o *options) error {
+		if o.config == nil {
+			o.config = config.Config{}
+		}
+		o.initialize = true
+		return nil
+	}
+}
+
+// Initialize dismisses the welcome screen and any other first-run screens
+// links2 shows after Open, and verifies links2 is idle by opening and closing
+// the dropdown menu. Unlike the welcome screen check made by the first
+// operation, it doesn't depend on the screen being drawn within the Open
+// timeout.
+func (b *Browser) Initialize() error {
+	return b.InitializeContext(context.Background())
+}
+
+// InitializeContext is like Initialize but bounds waits by ctx.
+func (b *Browser) InitializeContext(ctx context.Context) error {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.initialize()
+}
+
+func (b *Browser) initialize() error {
+	switch b.s {
+	case stateUndefined:
+		return ErrNotStarted
+	case stateStarted:
+		// Screens are dismissed below.
+		b.s = stateIdle
+	default:
+		if err := b.closeMenu(); err != nil {
+			return err
+		}
+	}
+	keys, err := b.keys(ActionMenu)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < maxFirstRunScreens; i++ {
+		// The welcome screen may take the keys, if they're Esc, so it's
+		// watched for too.
+		b.c.Send(keys)
+		buf, err := b.expect(b.timeouts.Menu, b.match(dropdownMenu, welcomeScreen))
+		if err != nil {
+			return fmt.Errorf("initialize: %w", b.keymapError(ActionMenu, err))
+		}
+		if b.matched(buf, dropdownMenu) {
+			b.s, b.menuName = stateMenu, menuDropdown
+			return b.closeMenu()
+		}
+		if keys != "\033" {
+			b.c.Send("\033") // Esc dismisses the screen.
+		}
+		// Let the dismissed screen clear before trying again.
+		if _, err := b.drain(); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("initialize: %w: too many first-run screens", ErrMenuOpen)
+}
@@ -0,0 +1,50 @@
+package links2
+
+import "sync"
+
+// closedChan is a closed channel.
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// interrupter interrupts the screen waits of an operation holding the
+// Browser lock, so Close and Quit needn't wait for a wait which may never
+// end, e.g. WaitForText with a background context.
+type interrupter struct {
+	mu      sync.Mutex
+	ch      chan struct{}
+	pending int // pending counts the Close and Quit calls taking the lock.
+}
+
+// wait returns a channel which is closed once a Close or Quit call wants
+// the lock.
+func (i *interrupter) wait() <-chan struct{} {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.pending > 0 {
+		return closedChan
+	}
+	if i.ch == nil {
+		i.ch = make(chan struct{})
+	}
+	return i.ch
+}
+
+// start interrupts waits until stop is called.
+func (i *interrupter) start() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.pending++
+	if i.ch != nil {
+		close(i.ch)
+		i.ch = nil
+	}
+}
+
+func (i *interrupter) stop() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.pending--
+}
@@ -0,0 +1,160 @@
+package links2
+
+import (
+	"fmt"
+)
+
+// Key is a named key which can be sent to links2 with SendKey.
+type Key int
+
+const (
+	KeyEsc Key = iota
+	KeyEnter
+	KeyTab
+	KeyBackspace
+	KeyUp
+	KeyDown
+	KeyRight
+	KeyLeft
+	KeyHome
+	KeyEnd
+	KeyPgUp
+	KeyPgDn
+	KeyInsert
+	KeyDelete
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+	numNamedKeys
+)
+
+// Ctrl and Alt combinations are encoded after the named keys.
+const (
+	keyCtrl Key = 1 << 8
+	keyAlt  Key = 2 << 8
+)
+
+// keySeqs are the xterm sequences of the named keys.
+var keySeqs = [...]string{
+	KeyEsc:       "\033",
+	KeyEnter:     "\n",
+	KeyTab:       "\t",
+	KeyBackspace: "\177",
+	KeyUp:        "\033[A",
+	KeyDown:      "\033[B",
+	KeyRight:     "\033[C",
+	KeyLeft:      "\033[D",
+	KeyHome:      "\033[H",
+	KeyEnd:       "\033[F",
+	KeyPgUp:      "\033[5~",
+	KeyPgDn:      "\033[6~",
+	KeyInsert:    "\033[2~",
+	KeyDelete:    "\033[3~",
+	KeyF1:        "\033OP",
+	KeyF2:        "\033OQ",
+	KeyF3:        "\033OR",
+	KeyF4:        "\033OS",
+	KeyF5:        "\033[15~",
+	KeyF6:        "\033[17~",
+	KeyF7:        "\033[18~",
+	KeyF8:        "\033[19~",
+	KeyF9:        "\033[20~",
+	KeyF10:       "\033[21~",
+	KeyF11:       "\033[23~",
+	KeyF12:       "\033[24~",
+}
+
+var keyNames = [...]string{
+	KeyEsc:       "Esc",
+	KeyEnter:     "Enter",
+	KeyTab:       "Tab",
+	KeyBackspace: "Backspace",
+	KeyUp:        "Up",
+	KeyDown:      "Down",
+	KeyRight:     "Right",
+	KeyLeft:      "Left",
+	KeyHome:      "Home",
+	KeyEnd:       "End",
+	KeyPgUp:      "PgUp",
+	KeyPgDn:      "PgDn",
+	KeyInsert:    "Insert",
+	KeyDelete:    "Delete",
+	KeyF1:        "F1",
+	KeyF2:        "F2",
+	KeyF3:        "F3",
+	KeyF4:        "F4",
+	KeyF5:        "F5",
+	KeyF6:        "F6",
+	KeyF7:        "F7",
+	KeyF8:        "F8",
+	KeyF9:        "F9",
+	KeyF10:       "F10",
+	KeyF11:       "F11",
+	KeyF12:       "F12",
+}
+
+// Ctrl returns the key for Ctrl and the letter c, e.g. Ctrl('r') for ^R.
+func Ctrl(c byte) Key { return keyCtrl | Key(c|0x20) }
+
+// Alt returns the key for Alt and the character c, e.g. Alt('f') for the File menu.
+func Alt(c byte) Key { return keyAlt | Key(c) }
+
+// seq returns the byte sequence sent for k, or "" if k is invalid.
+func (k Key) seq() string {
+	switch {
+	case k >= 0 && k < numNamedKeys:
+		return keySeqs[k]
+	case k&^0xff == keyCtrl && k&0xff >= 'a' && k&0xff <= 'z':
+		return string(rune(k&0xff - 'a' + 1))
+	case k&^0xff == keyAlt && k&0xff >= ' ' && k&0xff < 0x7f:
+		return "\033" + string(rune(k&0xff))
+	default:
+		return ""
+	}
+}
+
+func (k Key) String() string {
+	switch {
+	case k >= 0 && k < numNamedKeys:
+		return keyNames[k]
+	case k&^0xff == keyCtrl:
+		return fmt.Sprintf("Ctrl-%c", rune(k&0xff)-0x20)
+	case k&^0xff == keyAlt:
+		return fmt.Sprintf("Alt-%c", rune(k&0xff))
+	default:
+		return fmt.Sprintf("Key(%d)", int(k))
+	}
+}
+
+// SendKey presses the keys in order after closing any open menu.
+// This is the escape hatch for features the package doesn't wrap. The Browser
+// doesn't track menus or dialogs opened by the keys.
+func (b *Browser) SendKey(keys ...Key) error {
+	var seq string
+	for _, k := range keys {
+		s := k.seq()
+		if s == "" {
+			return fmt.Errorf("invalid key: %v", k)
+		}
+		seq += s
+	}
+	return b.send(seq)
+}
+
+// SendText types s after closing any open menu. Control characters are
+// rejected since links2 would interpret them as key presses; use SendKey.
+func (b *Browser) SendText(s string) error {
+	if err := checkInput(s); err != nil {
+		return err
+	}
+	return b.send(s)
+}
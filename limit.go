@@ -0,0 +1,89 @@
+package links2
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter delays navigations to a host, e.g. a *rate.Limiter from
+// golang.org/x/time/rate.
+type Limiter interface {
+	// Wait blocks until a navigation may proceed or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// WithLimiter waits on the Limiter returned by limiter for the host of each
+// URL before navigating to it. limiter may return nil for hosts which are
+// not limited and must be safe for concurrent use when shared, e.g. by a Pool.
+func WithLimiter(limiter func(host string) Limiter) Option {
+	return func(o *options) error {
+		o.limiter = limiter
+		return nil
+	}
+}
+
+// WithHostDelay waits at least d between the start of navigations to the
+// same host. Browsers opened with the same Option, e.g. by a Pool, share
+// their delays.
+func WithHostDelay(d time.Duration) Option {
+	var (
+		mu    sync.Mutex
+		hosts = make(map[string]*delayLimiter)
+	)
+	return WithLimiter(func(host string) Limiter {
+		mu.Lock()
+		defer mu.Unlock()
+		l, ok := hosts[host]
+		if !ok {
+			l = &delayLimiter{delay: d}
+			hosts[host] = l
+		}
+		return l
+	})
+}
+
+// delayLimiter is a Limiter which spaces events at least delay apart.
+type delayLimiter struct {
+	delay time.Duration
+	mu    sync.Mutex
+	next  time.Time // next is the earliest time of the next event.
+}
+
+func (l *delayLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.delay)
+	l.mu.Unlock()
+	if at.Equal(now) {
+		return nil
+	}
+	t := time.NewTimer(at.Sub(now))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitLimit waits on the Limiter of WithLimiter for host, if any.
+func (b *Browser) waitLimit(host string) error {
+	if b.opts == nil || b.opts.limiter == nil || host == "" {
+		return nil
+	}
+	l := b.opts.limiter(host)
+	if l == nil {
+		return nil
+	}
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return l.Wait(ctx)
+}
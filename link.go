@@ -0,0 +1,201 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Link is a hyperlink in the current document.
+type Link struct {
+	Text  string
+	URL   string
+	Index int // Index is the position of the link in document order as enumerated by Links.
+}
+
+// CurrentLink returns the text and target URL of the selected link.
+//
+// If there is no selected link, ErrNoLink is returned.
+// The URL is read from the Info dialog while the text is read from the
+// highlight drawn when the link was selected.
+func (b *Browser) CurrentLink() (Link, error) {
+	return b.CurrentLinkContext(context.Background())
+}
+
+// CurrentLinkContext is like CurrentLink but bounds waits by ctx.
+func (b *Browser) CurrentLinkContext(ctx context.Context) (Link, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	info, before, err := b.documentInfo()
+	if err != nil {
+		return Link{}, err
+	}
+	if info.Link == "" {
+		return Link{}, ErrNoLink
+	}
+	link := Link{Text: highlightedText(before), URL: info.Link}
+	if link.Text == "" && link.URL == b.lastLink.URL {
+		// Nothing was redrawn since the last call.
+		link.Text = b.lastLink.Text
+	}
+	b.lastLink = link
+	return link, nil
+}
+
+// maxLinks bounds the number of links enumerated by Links.
+const maxLinks = 10000
+
+// Links returns every link in the current document in document order.
+//
+// Links are enumerated by selecting each link in turn starting from the top
+// of the document, so the selection is left on the last link. Enumeration
+// stops when selecting the next link leaves the selection unchanged.
+func (b *Browser) Links() ([]Link, error) {
+	return b.LinksContext(context.Background())
+}
+
+// LinksContext is like Links but bounds waits by ctx.
+func (b *Browser) LinksContext(ctx context.Context) ([]Link, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	var links []Link
+	err := b.eachLink(ctx, func(link Link) bool {
+		links = append(links, link)
+		return true
+	})
+	return links, err
+}
+
+// eachLink selects each link of the document in turn from the top, calling
+// fn with it until fn returns false. The selection is left on the last link
+// visited.
+//
+// The end of the document is found by the selection not moving: adjacent
+// links can have the same text and target, so the position of the
+// highlighted link in the document is compared instead, counting the rows
+// the document scrolled by as the selection moves down.
+func (b *Browser) eachLink(ctx context.Context, fn func(Link) bool) error {
+	if err := b.perform(ActionHome); err != nil {
+		return err
+	}
+	var (
+		rows   []string
+		offset int
+		last   linkPosition
+		prev   Link
+	)
+	for i := 0; i < maxLinks; i++ {
+		if _, err := b.drain(); err != nil {
+			return err
+		}
+		prevRows := rows
+		rows = b.documentRows()
+		if i > 0 {
+			offset += scrolled(prevRows, rows)
+		}
+		pos := b.linkPosition(offset, len(rows))
+		if i > 0 && pos.ok && pos == last {
+			break
+		}
+		link, err := b.CurrentLinkContext(ctx)
+		if errors.Is(err, ErrNoLink) && i == 0 {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if i > 0 && !pos.ok && prev.URL == link.URL && prev.Text == link.Text {
+			// Without a highlight to locate, alike links end the document.
+			break
+		}
+		link.Index = i
+		last, prev = pos, link
+		if !fn(link) {
+			return nil
+		}
+		if err := b.perform(ActionNextLink); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkPosition is the position of the selected link in the document.
+type linkPosition struct {
+	line, col int
+	ok        bool // ok is set if the selected link is highlighted on screen.
+}
+
+// linkPosition returns the position of the link highlighted in the n
+// document rows on screen, the first of which is document line offset.
+func (b *Browser) linkPosition(offset, n int) linkPosition {
+	runs := b.scr.attrRuns(AttrReverse, 1, n)
+	if len(runs) == 0 {
+		return linkPosition{}
+	}
+	// Row 0 of the screen is the title bar.
+	return linkPosition{line: offset + runs[0].Y - 1, col: runs[0].X, ok: true}
+}
+
+// scrolled returns how many rows the document scrolled up between the
+// screen rows prev and rows: the smallest shift which makes them overlap, or
+// all of the rows if none does.
+func scrolled(prev, rows []string) int {
+	if len(prev) != len(rows) {
+		return len(rows)
+	}
+	for k := range rows {
+		if equalLines(prev[k:], rows[:len(rows)-k]) {
+			return k
+		}
+	}
+	return len(rows)
+}
+
+// FollowLinkMatching selects the first link in document order for which
+// pred returns true and follows it, waiting for the page to load. If no link
+// matches, the error wraps ErrNoLink.
+func (b *Browser) FollowLinkMatching(pred func(Link) bool) error {
+	return b.FollowLinkMatchingContext(context.Background(), pred)
+}
+
+// FollowLinkMatchingContext is like FollowLinkMatching but bounds waits by ctx.
+func (b *Browser) FollowLinkMatchingContext(ctx context.Context, pred func(Link) bool) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	var (
+		found bool
+		match Link
+	)
+	err := b.eachLink(ctx, func(link Link) bool {
+		found, match = pred(link), link
+		return !found
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("follow link: no matching link: %w", ErrNoLink)
+	}
+	if b.interceptMailto(match) {
+		return nil
+	}
+	return b.sendLoad(ActionFollowLink)
+}
+
+// LinkText matches links whose text is s.
+func LinkText(s string) func(Link) bool {
+	return func(l Link) bool { return l.Text == s }
+}
+
+// LinkTextContains matches links whose text contains s.
+func LinkTextContains(s string) func(Link) bool {
+	return func(l Link) bool { return strings.Contains(l.Text, s) }
+}
+
+// LinkURLMatches matches links whose URL matches re.
+func LinkURLMatches(re *regexp.Regexp) func(Link) bool {
+	return func(l Link) bool { return re.MatchString(l.URL) }
+}
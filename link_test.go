@@ -0,0 +1,22 @@
+package links2
+
+import "testing"
+
+func TestScrolled(t *testing.T) {
+	tests := []struct {
+		prev, rows []string
+		want       int
+	}{
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, 0},
+		{[]string{"a", "b", "c"}, []string{"b", "c", "d"}, 1},
+		{[]string{"a", "b", "c"}, []string{"c", "d", "e"}, 2},
+		{[]string{"a", "b", "c"}, []string{"d", "e", "f"}, 3},
+		{[]string{"a", "b", "c"}, []string{"a", "b"}, 2},
+		{[]string{"x", "", ""}, []string{"", "", ""}, 1},
+	}
+	for _, tc := range tests {
+		if got := scrolled(tc.prev, tc.rows); got != tc.want {
+			t.Errorf("scrolled(%q, %q) = %d, want %d", tc.prev, tc.rows, got, tc.want)
+		}
+	}
+}
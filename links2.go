@@ -2,39 +2,20 @@ package links2
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
-	"net/url"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
-	"unicode/utf8"
 
 	"github.com/Netflix/go-expect"
-)
-
-const (
-	ctrlC = "\003" // ^C
-	esc   = "\033" // Esc
-)
-
-const (
-	dropdownMenu = "File  \033[0;7m  View    Link    Downloads    Setup    Help"
-	exitLinks    = "Exit Links \033[0;7m-------------+"
-	exitPrompt   = "Do you really want to exit Links?"
-	goToMenu     = "Go to URL \033[0;7m---------------------------+"
-)
-
-const (
-	lookupHost        = "Looking up host\033[0m"
-	makeConnection    = "Making connection\033[0m"
-	requestSent       = "Request sent\033[0m"
-	sslNegotiate      = "SSL negotiation\033[0m"
-	formatDocument    = "Formatting document\033[0m"
-	errorLoading      = "Error loading "
-	hostNotFound      = "Host not found"
-	errorText         = "Error \033[0;7m"
-	noSuchFile        = "No such file or directory\033[13;"
-	fileAlreadyExists = "File already exists \033[10;"
+	"github.com/ajzaff/links2/cookies"
 )
 
 type state int
@@ -44,72 +25,347 @@ const (
 	stateStarted                // stateStarted a call was made to Open or OpenContext; we might see a welcome screen.
 	stateIdle                   // stateIdle no other menus are open (we can run most commands).
 	stateMenu                   // stateMenu a menu is open.
+	stateDirty                  // stateDirty the screen was changed by Raw; we must resync before the next command.
 )
 
 const (
-	menuDropdown = "dropdown"
-	menuSearch   = "search"
-	menuRSearch  = "rsearch"
-	menuGoTo     = "goto"
+	menuDropdown  = "dropdown"
+	menuSearch    = "search"
+	menuRSearch   = "rsearch"
+	menuInfo      = "info"
+	menuHeader    = "header"
+	menuError     = "error"
+	menuDownload  = "download"
+	menuSelect    = "select"
+	menuBookmarks = "bookmarks"
+	menuHistory   = "history"
+	menuProxies   = "proxies"
 )
 
 // Browser represents an instance of a links2 process attached to an `expect`-like console controller.
+//
+// A Browser is safe for concurrent use by multiple goroutines. Operations are
+// serialized so key presses and expect waits of different operations never
+// interleave.
 type Browser struct {
+	mu sync.Mutex // mu serializes operations.
+	instance
+	// proc is the last links2 process started, which is kept by Close.
+	proc struct {
+		exit    *exitStatus
+		stderr  *tailBuffer
+		output  *tailBuffer // output is the output tail of WithOutputCapture.
+		cookies cookieStore
+	}
+	// setCookies are the cookies of SetCookies for the next Open.
+	setCookies []*http.Cookie
+	load       loadAbort // load is the page load in progress, if any.
+	// closing interrupts screen waits for Close and Quit.
+	closing interrupter
+}
+
+// instance is the state of an open Browser which is reset by Close.
+type instance struct {
 	cmd        *exec.Cmd
 	s          state
-	c          *expect.Console
+	c          Console
+	scr        *screen
 	menuName   string
 	viewSource bool
+	lastLink   Link
+	downloads  []*Download
+	timeouts   Timeouts
+	ctx        context.Context // ctx bounds expect waits of the current operation.
+	opts       *options
+	exit       *exitStatus
+	lastURL    string // lastURL is the URL most recently loaded by Navigate.
+	events     *eventHub
+	log        *slog.Logger
+	patterns   *strings.Replacer // patterns translates UI text, if localized.
+	// regexps match the patterns of the release's PatternTable by regexp.
+	regexps    map[string]patternRegexp
+	home       string // home is the temporary home of WithConfig.
+	frames     frameState
+	rec        *Macro // rec is the macro being recorded, if any.
+	version    Version
+	scratch    string // scratch holds the documents of NavigateHTML.
+	htmlDocs   int    // htmlDocs counts the documents of NavigateHTML.
+	errDialogs *errorWatch
+	reader     *outputReader // reader reads the output of c.
+	selection  string        // selection is the text selected by SelectText.
+	matches    []Match       // matches are the matches of the last FindAll.
+	saveAs     string        // saveAs is the path DownloadURL saves the document to.
+	// stream copies the file saved by writeSaved as it's written, if any.
+	stream *saveStream
+	// headerProxy adds the header fields of WithHeaders, if any.
+	headerProxy *headerProxy
+	cast        *asciicast // cast is the recording of WithAsciicast, if any.
 }
 
 // Open the browser subprocess.
-func (b *Browser) Open() error { return b.OpenContext(context.Background()) }
+func (b *Browser) Open(opts ...Option) error { return b.OpenContext(context.Background(), opts...) }
 
 // Open the browser subprocess passing in the given context.
-func (b *Browser) OpenContext(ctx context.Context) error {
+func (b *Browser) OpenContext(ctx context.Context, opts ...Option) error {
+	_, done := b.begin(context.Background())
+	defer done()
 	switch b.s {
 	case stateUndefined:
 	default:
-		return fmt.Errorf("browser already started")
+		return ErrAlreadyStarted
 	}
-
-	cmd := exec.CommandContext(ctx, "links2")
-	c, err := expect.NewConsole(expect.WithLogger(log.Default()))
+	o, err := newOptions(opts)
 	if err != nil {
 		return err
 	}
-	cmd.Stdin = c.Tty()
-	cmd.Stdout = c.Tty()
-	cmd.Stderr = c.Tty()
+	if err := b.applyCookies(o); err != nil {
+		return err
+	}
+	ctx, span := startSpan(o.tracer, ctx, "links2.Open")
+	err = b.start(ctx, o)
+	if err == nil && o.initialize {
+		if err = b.initialize(); err != nil {
+			b.close()
+		}
+	}
+	span.End(time.Now(), err)
+	return err
+}
 
-	if err := cmd.Start(); err != nil {
+// start starts the browser subprocess with options o.
+func (b *Browser) start(ctx context.Context, o *options) error {
+	name, args := o.driver.Command()
+	args = append(args[:len(args):len(args)], o.args...)
+	headerProxy := b.headerProxy
+	if o.headers != nil {
+		if headerProxy == nil {
+			var err error
+			if headerProxy, err = startHeaderProxy(o.headers); err != nil {
+				return err
+			}
+			defer func() {
+				if b.headerProxy != headerProxy {
+					headerProxy.close() // start failed.
+				}
+			}()
+		}
+		// The last -http-proxy wins over those of WithProxy.
+		args = append(args, "-http-proxy", headerProxy.addr())
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = o.downloadDir
+	home := b.home
+	switch {
+	case o.profile != nil:
+		if err := configureProfile(o); err != nil {
+			return err
+		}
+		cmd.Env = append(os.Environ(), "HOME="+o.profile.Dir)
+	case o.config != nil:
+		if home == "" {
+			var err error
+			if home, err = makeHome(o); err != nil {
+				return err
+			}
+			defer func() {
+				if b.home != home {
+					os.RemoveAll(home) // start failed.
+				}
+			}()
+		}
+		cmd.Env = append(os.Environ(), "HOME="+home)
+	}
+	var version Version
+	if o.console == nil {
+		version = detectVersion(ctx, o)
+		if o.graphics != "" {
+			if err := checkVersion(version, "graphics mode", 2, 0); err != nil {
+				return err
+			}
+		}
+	}
+	cols, rows := defaultCols, defaultRows
+	if o.cols > 0 {
+		cols, rows = o.cols, o.rows
+	}
+	scr := newScreen(cols, rows)
+	var table PatternTable
+	if o.driver == Links2 {
+		table = lookupPatternTable(version)
+		o.logger.Debug("pattern table", "version", version, "release", fmt.Sprintf("%d.%d", table.Major, table.Minor))
+	}
+	patterns := table.merge(o.patterns).replacer()
+	errDialogs := newErrorWatch(translate(patterns, errorText), translate(patterns, okButton))
+	reader := newOutputReader()
+	out := io.MultiWriter(scr, errDialogs, reader)
+	cast := b.cast
+	if cast == nil && o.asciicast != nil {
+		cast = newAsciicast(o.asciicast, cols, rows)
+	}
+	if cast != nil {
+		out = io.MultiWriter(out, cast)
+	}
+	var output *tailBuffer
+	if o.capture {
+		output = new(tailBuffer)
+		out = io.MultiWriter(out, output)
+		if o.captureOut != nil {
+			out = io.MultiWriter(out, o.captureOut)
+		}
+	}
+	var (
+		c   Console
+		err error
+	)
+	if o.tmuxSession != "" && o.console == nil {
+		c, err = startTmux(ctx, o.tmuxSession, cmd, cols, rows, consoleOut(o, out))
+	} else {
+		c, err = b.newConsole(o, out)
+	}
+	if err != nil {
 		return err
 	}
+	exit := &exitStatus{done: make(chan struct{})}
+	var stderr *tailBuffer
+	if tty := c.Tty(); tty != nil {
+		if o.cols > 0 {
+			if err := setWinsize(tty, cols, rows); err != nil {
+				c.Close()
+				return err
+			}
+		}
+		cmd.Stdin = tty
+		cmd.Stdout = tty
+		stderr = new(tailBuffer)
+		cmd.Stderr = stderr
+		setProcessGroup(cmd)
+		cmd.Cancel = func() error { return exit.terminate(cmd) }
+
+		if err := cmd.Start(); err != nil {
+			c.Close()
+			return err
+		}
+	} else {
+		// The console stands in for links2.
+		cmd = nil
+	}
+
+	go reader.run(c)
 
 	b.cmd = cmd
 	b.c = c
+	b.reader = reader
+	b.scr = scr
+	b.home = home
+	b.headerProxy = headerProxy
+	b.cast = cast
+	b.opts = o
+	b.log = o.logger
+	b.patterns = patterns
+	b.regexps = table.compileRegexps()
+	b.errDialogs = errDialogs
+	b.timeouts = o.timeouts
+	b.version = version
+	if b.events == nil {
+		b.events = &eventHub{}
+	}
+	if cmd != nil {
+		exit.monitor(cmd, c.Tty(), b.events)
+	}
+	b.proc.exit, b.proc.stderr, b.proc.output = exit, stderr, output
+	switch {
+	case o.profile != nil:
+		b.proc.cookies = cookieStore{path: filepath.Join(o.profile.linksDir(), "cookies")}
+	case home != "":
+		b.proc.cookies = cookieStore{}
+	default:
+		path, _ := cookies.DefaultPath()
+		b.proc.cookies = cookieStore{path: path}
+	}
+	b.setCookies = nil
+	b.exit = exit
 	b.s = stateStarted
 	return nil
 }
 
 // Close stops the browser subprocess and resets it.
 func (b *Browser) Close() error {
+	b.closing.start()
+	defer b.closing.stop()
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.close()
+}
+
+func (b *Browser) close() error {
+	if b.s == stateUndefined {
+		return ErrNotStarted
+	}
 	err := b.c.Close()
-	err1 := b.cmd.Cancel()
-	if err == nil {
+	if b.cmd != nil {
+		err1 := b.cmd.Cancel()
+		if errors.Is(err1, os.ErrProcessDone) {
+			err1 = nil
+		}
+		if err == nil {
+			err = err1
+		}
+	}
+	if err1 := b.release(); err == nil {
 		err = err1
 	}
-	*b = Browser{}
 	return err
 }
 
-func (b *Browser) Wait() (err error) {
-	defer func() {
-		if err1 := b.Close(); err == nil {
+// release frees what the instance holds besides the process and resets it.
+func (b *Browser) release() error {
+	b.snapshotCookies()
+	b.events.close()
+	if b.headerProxy != nil {
+		b.headerProxy.close()
+	}
+	var err error
+	for _, dir := range []string{b.home, b.scratch} {
+		if dir == "" {
+			continue
+		}
+		if err1 := os.RemoveAll(dir); err == nil {
 			err = err1
 		}
-	}()
-	return b.cmd.Wait()
+	}
+	b.instance = instance{}
+	return err
+}
+
+// Wait waits for the browser subprocess to exit and then closes it,
+// returning how it exited. The error is that of closing the browser, or
+// ErrNotStarted; a failed exit is reported by the Exit.
+// Other operations may be used while waiting, e.g. Quit.
+func (b *Browser) Wait() (Exit, error) {
+	return b.WaitContext(context.Background())
+}
+
+// WaitContext is like Wait but gives up when ctx is done, returning its
+// error and leaving the browser open.
+func (b *Browser) WaitContext(ctx context.Context) (Exit, error) {
+	_, done := b.begin(context.Background())
+	exit := b.exit
+	done()
+	if exit == nil {
+		return Exit{}, ErrNotStarted
+	}
+	select {
+	case <-exit.done:
+	case <-ctx.Done():
+		return Exit{}, ctx.Err()
+	}
+	_, done = b.begin(context.Background())
+	defer done()
+	if b.exit != exit {
+		// Closed while waiting.
+		return exit.result(), nil
+	}
+	return exit.result(), b.close()
 }
 
 func (b *Browser) expectWelcomeScreen() bool {
@@ -118,10 +374,9 @@ func (b *Browser) expectWelcomeScreen() bool {
 	default:
 		return false
 	}
-	_, err := b.c.Expect(
-		expect.String("Welcome"),
-		expect.String("Welcome to links!"),
-		expect.WithTimeout(0),
+	_, err := b.expect(b.timeouts.Open,
+		expect.String(b.tr("Welcome")),
+		expect.String(b.tr("Welcome to links!")),
 	)
 	return err == nil
 }
@@ -134,15 +389,35 @@ func (b *Browser) sendIdle(s string) error {
 	return err
 }
 
-func (b *Browser) expectGoToMenu() { b.c.ExpectString(goToMenu) }
+func (b *Browser) expectGoToMenu() error { return b.expectOpened(ActionGoTo, goToMenu) }
 
-func (b *Browser) expectDropDownMenu() {
+func (b *Browser) expectDropDownMenu() error {
 	switch b.s {
 	case stateMenu:
-		return
+		return nil
+	}
+	if err := b.expectOpened(ActionMenu, dropdownMenu); err != nil {
+		return err
 	}
-	b.c.ExpectString(dropdownMenu)
 	b.s = stateMenu
+	return nil
+}
+
+// expectOpened waits for the menu or dialog s opened by the keys of action
+// a, reporting keys which don't open it with ErrKeymap.
+func (b *Browser) expectOpened(a Action, s string) error {
+	_, err := b.expectString(s)
+	return b.keymapError(a, err)
+}
+
+// keymapError wraps a timeout waiting for what the keys of action a open
+// with ErrKeymap.
+func (b *Browser) keymapError(a Action, err error) error {
+	if !errors.Is(err, ErrTimeout) {
+		return err
+	}
+	keys, _ := b.keys(a)
+	return fmt.Errorf("%w: %v keys %q: %w", ErrKeymap, a, keys, err)
 }
 
 func (b *Browser) openDropDownMenu() error {
@@ -153,144 +428,235 @@ func (b *Browser) openDropDownMenu() error {
 	if err := b.closeMenu(); err != nil {
 		return err
 	}
-	b.c.Send("\033") // Esc
-	b.expectDropDownMenu()
-	return nil
+	keys, err := b.keys(ActionMenu)
+	if err != nil {
+		return err
+	}
+	b.log.Debug("open menu", "menu", menuDropdown)
+	b.c.Send(keys)
+	return b.expectDropDownMenu()
 }
 
 func (b *Browser) closeMenu() error {
+	if err := b.checkExited(); err != nil {
+		return err
+	}
 	switch b.s {
 	case stateUndefined:
-		return fmt.Errorf("browser not started")
+		return ErrNotStarted
 	case stateStarted:
 		if !b.expectWelcomeScreen() {
 			b.s = stateIdle
-			return nil
+			return b.dismissErrors()
 		}
 	case stateIdle:
-		return nil
+		return b.dismissErrors()
 	case stateMenu:
+		// Error dialogs drawn since were expected by the operation.
+		defer b.errDialogs.take()
+	case stateDirty:
+		return b.resync()
+	}
+	b.log.Debug("close menu", "menu", b.menuName)
+	if _, err := b.c.Send("\033"); err != nil { // Esc
+		return fmt.Errorf("%w: %s: %v", ErrMenuOpen, b.menuName, err)
 	}
-	b.c.Send("\033") // Esc
 	b.s = stateIdle
 	b.menuName = ""
 	return nil
 }
 
-// Navigate the browser to the given URL.
-func (b *Browser) Navigate(rawURL string) error {
-	// This serves to sanitize URL to ensure it has no terminal commands within.
-	if !utf8.ValidString(rawURL) {
-		return fmt.Errorf("url is not a valid unicode string: %q", rawURL)
-	}
-	// Parse the URL and possibly fix the scheme.
-	// Links2 sometimes adds a scheme which can be weird.
-	u, err := url.Parse(rawURL)
-	if err != nil {
+// SaveFormattedDocument saves the formatted text of the current document to
+// the file name and waits until it's written. An existing file is replaced
+// if overwrite is set and otherwise ErrFileExists is returned. A relative
+// name is resolved against the directory of WithDownloadDir, if any.
+func (b *Browser) SaveFormattedDocument(name string, overwrite bool) error {
+	return b.SaveFormattedDocumentContext(context.Background(), name, overwrite)
+}
+
+// SaveFormattedDocumentContext is like SaveFormattedDocument but bounds waits by ctx.
+func (b *Browser) SaveFormattedDocumentContext(ctx context.Context, name string, overwrite bool) (err error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	ctx, span := b.startSpan(ctx, "links2.SaveFormattedDocument")
+	span.SetAttribute("name", name)
+	defer func() { span.End(time.Now(), err) }()
+	if err := b.checkAnonymous("save " + name); err != nil {
 		return err
 	}
-	if u.Host == "" {
-		u.Scheme = "file"
-	}
-	// Open GoTo menu.
-	if err := b.sendIdle("g"); err != nil {
+	if err := checkInput(name); err != nil {
 		return err
 	}
-	b.expectGoToMenu()
-
-	// Hack? Ending with Esc (menu) and calling expectMenu is
-	// the easiest way to determine when the page load finishes.
-	fmt.Fprint(b.c, u.String(), "\n\033")
-	b.expectDropDownMenu()
-	return nil
-}
-
-func (b *Browser) ViewSource() {
-	if !b.viewSource {
-		b.c.Send("\\")
-		b.viewSource = true
+	name = b.resolvePath(name)
+	old, _ := os.Stat(name)
+	if err := b.OpenMenuContext(ctx, "File", "Save formatted document"); err != nil {
+		return err
 	}
-}
-
-func (b *Browser) ViewHTML() {
-	if b.viewSource {
-		b.c.Send("\\")
-		b.viewSource = false
+	if _, err := b.expectString(saveAsDialog); err != nil {
+		b.closeMenu()
+		return err
 	}
-}
-
-// SaveFormattedDocument.
-func (b *Browser) SaveFormattedDocument(name string, overwrite bool) {
-	b.openDropDownMenu()
-	b.c.Send("\033fd") // Alt-F d
-	fmt.Fprint(b.c, "\033fd", name, "\n")
-	// Handle "file already exists".
-	if _, err := b.c.Expect(
-		expect.String(fileAlreadyExists),
-		expect.WithTimeout(10*time.Microsecond),
-	); err == nil {
-		if overwrite {
-			b.c.Send("\n")
-		} else {
-			b.c.Send("\033") // Esc
+	// Replace the suggested file name.
+	b.c.Send("\025" + name + "\n") // ^U
+	buf, err := b.expect(b.timeouts.Dialog, b.match(fileAlreadyExists, noSuchFile))
+	switch {
+	case err == nil && b.matched(buf, fileAlreadyExists):
+		if !overwrite {
+			b.c.Send("\033\033") // Esc
+			b.s = stateIdle
+			b.menuName = ""
+			return fmt.Errorf("save %s: %w", name, ErrFileExists)
 		}
+		b.c.Send("\n") // Enter confirms.
+	case err == nil:
+		// Leave the message box for closeMenu.
+		b.menuName = menuError
+		return fmt.Errorf("save %s: %w", name, ErrNoSuchFile)
+	case !errors.Is(err, os.ErrDeadlineExceeded):
+		return err
 	}
+	b.s = stateIdle
+	b.menuName = ""
+	return b.waitFile(name, old)
 }
 
 // Quit the browser gracefully and return the error if any.
-func (b *Browser) Quit() (err error) {
+// The exit confirmation and the warning about downloads in progress are
+// answered, and Quit waits for links2 to exit before closing the browser. A
+// links2 which doesn't exit in time is killed.
+func (b *Browser) Quit() error {
+	return b.QuitContext(context.Background())
+}
+
+// QuitContext is like Quit but bounds waits by ctx.
+func (b *Browser) QuitContext(ctx context.Context) (err error) {
+	b.closing.start()
+	defer b.closing.stop()
+	ctx, done := b.begin(ctx)
+	defer done()
+	_, span := b.startSpan(ctx, "links2.Quit")
+	defer func() { span.End(time.Now(), err) }()
 	if err := b.closeMenu(); err != nil {
 		return err
 	}
 	defer func() {
-		if err1 := b.Close(); err == nil {
+		if err1 := b.close(); err == nil {
 			err = err1
 		}
 	}()
-	_, err = b.c.Send("\003") // ^C
-	return err
+	keys, err := b.keys(ActionQuit)
+	if err != nil {
+		return err
+	}
+	if _, err := b.c.Send(keys); err != nil {
+		return err
+	}
+	return b.confirmExit()
 }
 
-func (b *Browser) ScrollUp()   { b.sendIdle("\033[5~") }
-func (b *Browser) ScrollDown() { b.sendIdle("\033[6~") }
+// maxExitPrompts bounds the exit confirmations answered by Quit.
+const maxExitPrompts = 2
 
-func (b *Browser) ScrollLeft()  { b.sendIdle("[") }
-func (b *Browser) ScrollRight() { b.sendIdle("]") }
+// confirmExit answers the exit confirmation dialogs with their default Yes
+// button and waits for links2 to exit, bounded by the Menu timeout. Without
+// a process, e.g. when attached, it only answers the dialogs.
+func (b *Browser) confirmExit() error {
+	prompt, downloads := b.tr(exitPrompt), b.tr(exitDownloads)
+	for i := 0; i < maxExitPrompts; i++ {
+		if b.exited() {
+			return nil
+		}
+		_, err := b.expect(b.timeouts.Dialog, expect.String(prompt, downloads))
+		if err != nil {
+			break
+		}
+		b.c.Send("\n") // Enter presses Yes.
+	}
+	if b.cmd == nil {
+		return nil
+	}
+	t := time.NewTimer(b.timeouts.Menu)
+	defer t.Stop()
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	select {
+	case <-b.exit.done:
+		return nil
+	case <-t.C:
+		return fmt.Errorf("quit: links2 did not exit: %w", ErrTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// exited reports whether the links2 process exited.
+func (b *Browser) exited() bool {
+	if b.cmd == nil {
+		return false
+	}
+	select {
+	case <-b.exit.done:
+		return true
+	default:
+		return false
+	}
+}
 
-// TODO: Provide a means to get the text and URL of the current link.
+func (b *Browser) ScrollUp()   { b.action(ActionScrollUp) }
+func (b *Browser) ScrollDown() { b.action(ActionScrollDown) }
 
-func (b *Browser) SelectNextLink() { b.sendIdle("\033[B") }
-func (b *Browser) SelectPrevLink() { b.sendIdle("\033[A") }
-func (b *Browser) FollowLink()     { b.sendIdle("\033[C") }
-func (b *Browser) BackLink()       { b.sendIdle("\033[D") }
+// ScrollUpN scrolls up n pages, sending the keys at once and returning once
+// links2 redrew the screen.
+func (b *Browser) ScrollUpN(n int) error { return b.ScrollUpNContext(context.Background(), n) }
+
+// ScrollUpNContext is like ScrollUpN but bounds waits by ctx.
+func (b *Browser) ScrollUpNContext(ctx context.Context, n int) error {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.performN(ActionScrollUp, n)
+}
 
-func (b *Browser) Reload()   { b.sendIdle("\022\033"); b.expectDropDownMenu() }
-func (b *Browser) JumpEnd()  { b.sendIdle("\033[F") }
-func (b *Browser) JumpHome() { b.sendIdle("\033[H") }
+// ScrollDownN scrolls down n pages like ScrollUpN.
+func (b *Browser) ScrollDownN(n int) error { return b.ScrollDownNContext(context.Background(), n) }
 
-// TODO: Handle Search string not found and allow extracting and clearing results.
+// ScrollDownNContext is like ScrollDownN but bounds waits by ctx.
+func (b *Browser) ScrollDownNContext(ctx context.Context, n int) error {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.performN(ActionScrollDown, n)
+}
 
-func (b *Browser) Search()         { b.sendIdle("/") }
-func (b *Browser) SearchBackward() { b.sendIdle("?") }
-func (b *Browser) FindNext()       { b.sendIdle("n") }
-func (b *Browser) FindPrevious()   { b.sendIdle("N") }
+func (b *Browser) ScrollLeft()  { b.action(ActionScrollLeft) }
+func (b *Browser) ScrollRight() { b.action(ActionScrollRight) }
 
-type DocumentInfo struct{}
+func (b *Browser) SelectNextLink() { b.action(ActionNextLink) }
+func (b *Browser) SelectPrevLink() { b.action(ActionPrevLink) }
 
-// FIXME: Allow extracting document info.
-func (b *Browser) DocumentInfo() DocumentInfo {
-	defer b.closeMenu()
-	b.sendIdle("=")
-	// TODO: Extract info.
-	return DocumentInfo{}
+// FollowLink follows the selected link without waiting for the page to
+// load. A mailto: link is passed to the function of WithMailto, if any.
+func (b *Browser) FollowLink() {
+	ctx, done := b.begin(context.Background())
+	defer done()
+	if ok, err := b.interceptSelectedMailto(ctx); ok || err != nil {
+		return
+	}
+	b.perform(ActionFollowLink)
 }
 
-type HTTPHeader struct{}
+func (b *Browser) Reload()   { b.ReloadContext(context.Background()) }
+func (b *Browser) JumpEnd()  { b.action(ActionEnd) }
+func (b *Browser) JumpHome() { b.action(ActionHome) }
 
-// FIXME: Allow extracting HTTP header.
-func (b *Browser) HTTPHeader() HTTPHeader {
-	defer b.closeMenu()
-	b.sendIdle("|")
-	// TODO: Extract info
-	return HTTPHeader{}
+// ReloadContext is like Reload but bounds waits by ctx.
+func (b *Browser) ReloadContext(ctx context.Context) error {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.sendLoad(ActionReload)
 }
+
+func (b *Browser) Search()         { b.action(ActionSearch) }
+func (b *Browser) SearchBackward() { b.action(ActionSearchBackward) }
+func (b *Browser) FindNext()       { b.action(ActionFindNext) }
+func (b *Browser) FindPrevious()   { b.action(ActionFindPrevious) }
@@ -0,0 +1,72 @@
+package links2
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestConcurrentOperations drives exported calls from several goroutines,
+// which must be serialized: run with -race.
+func TestConcurrentOperations(t *testing.T) {
+	var (
+		fake *fakeConsole
+		b    Browser
+	)
+	if err := b.Open(withFakeConsole(&fake)); err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	if err := b.Resync(); err != nil {
+		t.Fatal(err)
+	}
+
+	// active counts the Raw calls in progress, during which no other
+	// keys may be sent.
+	var active atomic.Int32
+	fake.mu.Lock()
+	fake.onSend = func(keys string) {
+		if active.Load() != 0 && keys != "raw" {
+			t.Errorf("sent %q during a Raw call", keys)
+		}
+	}
+	fake.mu.Unlock()
+
+	const goroutines, calls = 4, 5
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				err := b.Raw(func(c Console) error {
+					if n := active.Add(1); n != 1 {
+						t.Errorf("%d Raw calls in progress", n)
+					}
+					defer active.Add(-1)
+					_, err := c.Send("raw")
+					return err
+				})
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				b.Screen()
+				b.Cell(0, 0)
+				b.State()
+				b.OpenMenuName()
+				if _, err := b.StatusBar(); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := b.State(); got != StateIdle {
+		t.Errorf("State() = %v, want %v", got, StateIdle)
+	}
+}
@@ -0,0 +1,183 @@
+package links2test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajzaff/links2"
+)
+
+// conformanceTimeout bounds each test of the conformance suite.
+const conformanceTimeout = time.Minute
+
+// Conformance is an end-to-end test suite which drives a real links2 through
+// Navigate, link following, forms, saving and downloads against a Site.
+// It's exported so the suite can be run against a given environment and
+// links2 version, typically behind a build tag since it needs links2:
+//
+//	//go:build links2
+//
+//	func TestConformance(t *testing.T) {
+//		links2test.Conformance{Snapshots: "testdata/conformance"}.Run(t)
+//	}
+//
+// and then
+//
+//	go test -tags=links2 -run Conformance
+type Conformance struct {
+	// Options are the options each test opens its Browser with.
+	Options []links2.Option
+	// Snapshots is the directory of golden files of the rendered pages,
+	// compared with AssertGolden; they're written with the
+	// -links2test.update flag. If empty, pages are checked for their text
+	// only.
+	Snapshots string
+}
+
+// Run runs the suite as subtests of t. Each test opens a new Browser and is
+// skipped if links2 is not installed.
+func (c Conformance) Run(t *testing.T) {
+	site := NewSite()
+	defer site.Close()
+	tests := []struct {
+		name string
+		run  func(context.Context, *testing.T, *links2.Browser, *Site)
+	}{
+		{"Navigate", c.testNavigate},
+		{"FollowLink", c.testFollowLink},
+		{"Form", c.testForm},
+		{"Save", c.testSave},
+		{"Download", c.testDownload},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), conformanceTimeout)
+			defer cancel()
+			tc.run(ctx, t, c.open(ctx, t), site)
+		})
+	}
+}
+
+// open opens a Browser closed when t finishes.
+func (c Conformance) open(ctx context.Context, t *testing.T) *links2.Browser {
+	t.Helper()
+	b := new(links2.Browser)
+	if err := b.OpenContext(ctx, c.Options...); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			t.Skip("links2 is not installed")
+		}
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+// assertPage checks that the text of the current document contains want and
+// matches the snapshot name, if any.
+func (c Conformance) assertPage(ctx context.Context, t *testing.T, b *links2.Browser, name, want string) {
+	t.Helper()
+	text, err := b.PageTextContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, want) {
+		t.Errorf("page text does not contain %q:\n%s", want, text)
+	}
+	if c.Snapshots != "" {
+		AssertGolden(t, filepath.Join(c.Snapshots, name+".txt"), text)
+	}
+}
+
+func (c Conformance) testNavigate(ctx context.Context, t *testing.T, b *links2.Browser, site *Site) {
+	if _, err := b.NavigateContext(ctx, site.Page(IndexPath)); err != nil {
+		t.Fatal(err)
+	}
+	c.assertPage(ctx, t, b, "index", "This is the index of the links2test fixture site.")
+	title, err := b.TitleContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "Conformance index" {
+		t.Errorf("title = %q, want %q", title, "Conformance index")
+	}
+}
+
+func (c Conformance) testFollowLink(ctx context.Context, t *testing.T, b *links2.Browser, site *Site) {
+	if _, err := b.NavigateContext(ctx, site.Page(IndexPath)); err != nil {
+		t.Fatal(err)
+	}
+	err := b.FollowLinkMatchingContext(ctx, func(l links2.Link) bool { return l.Text == "Second page" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.assertPage(ctx, t, b, "second", "This is the second page.")
+	u, err := b.CurrentURLContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Path != SecondPath {
+		t.Errorf("current URL = %s, want path %s", u, SecondPath)
+	}
+}
+
+func (c Conformance) testForm(ctx context.Context, t *testing.T, b *links2.Browser, site *Site) {
+	if _, err := b.NavigateContext(ctx, site.Page(FormPath)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.SubmitFormValuesContext(ctx, map[string]string{"q": "conformance"}); err != nil {
+		t.Fatal(err)
+	}
+	c.assertPage(ctx, t, b, "search", "You searched for conformance.")
+}
+
+func (c Conformance) testSave(ctx context.Context, t *testing.T, b *links2.Browser, site *Site) {
+	if _, err := b.NavigateContext(ctx, site.Page(IndexPath)); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "index.txt")
+	if err := b.SaveFormattedDocumentContext(ctx, path, false); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(saved, []byte("This is the index of the links2test fixture site.")) {
+		t.Errorf("saved document does not contain the page text:\n%s", saved)
+	}
+	if err := b.SaveFormattedDocumentContext(ctx, path, false); !errors.Is(err, links2.ErrFileExists) {
+		t.Errorf("save over existing file: err = %v, want %v", err, links2.ErrFileExists)
+	}
+	var src bytes.Buffer
+	if err := b.WriteSourceContext(ctx, &src); err != nil {
+		t.Fatal(err)
+	}
+	if src.String() != IndexSource {
+		t.Errorf("source mismatch:\n%s", diff(IndexSource, src.String()))
+	}
+}
+
+func (c Conformance) testDownload(ctx context.Context, t *testing.T, b *links2.Browser, site *Site) {
+	path := filepath.Join(t.TempDir(), "download.bin")
+	d, err := b.DownloadURLContext(ctx, site.Page(DownloadPath), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Wait(ctx, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, DownloadContent) {
+		t.Errorf("downloaded %d bytes, want %d", len(got), len(DownloadContent))
+	}
+}
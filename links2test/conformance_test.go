@@ -0,0 +1,23 @@
+//go:build links2
+
+package links2test
+
+import (
+	"os"
+	"testing"
+)
+
+// TestConformance runs the conformance suite against the links2 on PATH:
+//
+//	go test -tags=links2 ./links2test
+//
+// The rendered pages are compared with the snapshots in
+// testdata/conformance, once written with -links2test.update for the
+// links2 version under test.
+func TestConformance(t *testing.T) {
+	var c Conformance
+	if _, err := os.Stat("testdata/conformance"); err == nil || *update {
+		c.Snapshots = "testdata/conformance"
+	}
+	c.Run(t)
+}
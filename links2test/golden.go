@@ -0,0 +1,99 @@
+package links2test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ajzaff/links2"
+)
+
+var update = flag.Bool("links2test.update", false, "update golden files")
+
+// Normalizer rewrites volatile parts of rendered text, e.g. dates.
+type Normalizer func(string) string
+
+// Replace returns a Normalizer replacing matches of re with repl.
+func Replace(re *regexp.Regexp, repl string) Normalizer {
+	return func(s string) string { return re.ReplaceAllString(s, repl) }
+}
+
+// Default normalizers.
+var (
+	// Dates replaces RFC 1123 and ISO 8601 dates and times with DATE.
+	Dates = Replace(regexp.MustCompile(
+		`(?:(?:Mon|Tue|Wed|Thu|Fri|Sat|Sun), \d{1,2} \w{3} \d{4} \d{2}:\d{2}:\d{2} \w+)`+
+			`|(?:\d{4}-\d{2}-\d{2}(?:[T ]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?)?)`), "DATE")
+	// Ports replaces the ports of local addresses with PORT.
+	Ports = Replace(regexp.MustCompile(`\b(localhost|127\.0\.0\.1|\[::1\]):\d+`), "$1:PORT")
+)
+
+// AssertGolden compares got, normalized by the given normalizers (Dates and
+// Ports if none are given), with the golden file at path. With the
+// -links2test.update flag the golden file is written instead.
+func AssertGolden(t testing.TB, path, got string, normalize ...Normalizer) {
+	t.Helper()
+	if len(normalize) == 0 {
+		normalize = []Normalizer{Dates, Ports}
+	}
+	for _, n := range normalize {
+		got = n(got)
+	}
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -links2test.update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch:\n%s", path, diff(string(want), got))
+	}
+}
+
+// AssertScreen compares the screen of b with the golden file at path.
+func AssertScreen(t testing.TB, b *links2.Browser, path string, normalize ...Normalizer) {
+	t.Helper()
+	AssertGolden(t, path, b.Screen()+"\n", normalize...)
+}
+
+// AssertDump compares the links2 -dump rendering of url at the given width
+// with the golden file at path.
+func AssertDump(t testing.TB, ctx context.Context, url string, width int, path string, normalize ...Normalizer) {
+	t.Helper()
+	got, err := links2.Dump(ctx, url, width)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertGolden(t, path, got, normalize...)
+}
+
+// diff returns the lines which differ between want and got.
+func diff(want, got string) string {
+	w, g := strings.Split(want, "\n"), strings.Split(got, "\n")
+	var sb strings.Builder
+	for i := 0; i < max(len(w), len(g)); i++ {
+		var wl, gl string
+		if i < len(w) {
+			wl = w[i]
+		}
+		if i < len(g) {
+			gl = g[i]
+		}
+		if wl != gl {
+			fmt.Fprintf(&sb, "line %d:\n-%s\n+%s\n", i+1, wl, gl)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package links2test
+
+import (
+	"errors"
+	"os"
+)
+
+// makeRaw is only implemented on Linux.
+func makeRaw(f *os.File) error {
+	return errors.New("links2test: replay not supported on this platform")
+}
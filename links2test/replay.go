@@ -0,0 +1,80 @@
+package links2test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ajzaff/links2"
+)
+
+// replayArg is the argument making the test binary replay a transcript.
+const replayArg = "-links2test.replay"
+
+// Main runs the tests of m, unless the test binary was started by Replay to
+// stand in for links2. Call it from TestMain.
+func Main(m interface{ Run() int }) {
+	if len(os.Args) > 2 && os.Args[1] == replayArg {
+		if err := replay(os.Args[2], os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "links2test:", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// Replay returns a Driver running the test binary in place of links2 to
+// replay the transcript at path, with the key bindings and patterns of d
+// (links2.Links2 if nil). The test binary must call Main from TestMain.
+func Replay(path string, d ...links2.Driver) links2.Driver {
+	base := links2.Links2
+	if len(d) > 0 && d[0] != nil {
+		base = d[0]
+	}
+	return replayDriver{Driver: base, path: path}
+}
+
+type replayDriver struct {
+	links2.Driver
+	path string
+}
+
+func (d replayDriver) Command() (string, []string) {
+	return os.Args[0], []string{replayArg, d.path}
+}
+
+// replay writes the output events of the transcript at path to out, reading
+// each input event from in before continuing. Once the transcript ends, in is
+// read until EOF so the session can be closed as usual.
+func replay(path string, in *os.File, out io.Writer) error {
+	t, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := makeRaw(in); err != nil {
+		return err
+	}
+	r := bufio.NewReader(in)
+	for i, e := range t {
+		if e.Out != "" {
+			if _, err := io.WriteString(out, e.Out); err != nil {
+				return err
+			}
+		}
+		if e.In == "" {
+			continue
+		}
+		got := make([]byte, len(e.In))
+		if _, err := io.ReadFull(r, got); err != nil {
+			return fmt.Errorf("event %d: want input %q: %v", i, e.In, err)
+		}
+		if !bytes.Equal(got, []byte(e.In)) {
+			return fmt.Errorf("event %d: got input %q, want %q", i, got, e.In)
+		}
+	}
+	_, err = io.Copy(io.Discard, r)
+	return err
+}
@@ -0,0 +1,98 @@
+package links2test
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+)
+
+// Paths of the pages of a Site.
+const (
+	IndexPath    = "/"
+	SecondPath   = "/second"
+	FormPath     = "/form"
+	SearchPath   = "/search"
+	DownloadPath = "/download"
+)
+
+// IndexSource is the HTML served at IndexPath.
+const IndexSource = `<!DOCTYPE html>
+<html>
+<head><title>Conformance index</title></head>
+<body>
+<h1>Conformance index</h1>
+<p>This is the index of the links2test fixture site.</p>
+<ul>
+<li><a href="/second">Second page</a></li>
+<li><a href="/form">Search form</a></li>
+<li><a href="/download">Download</a></li>
+</ul>
+</body>
+</html>
+`
+
+const secondSource = `<!DOCTYPE html>
+<html>
+<head><title>Second page</title></head>
+<body>
+<p>This is the second page.</p>
+<p><a href="/">Back to the index</a></p>
+</body>
+</html>
+`
+
+const formSource = `<!DOCTYPE html>
+<html>
+<head><title>Search form</title></head>
+<body>
+<form action="/search" method="get">
+<p>Query: <input type="text" name="q"> <input type="submit" value="Search"></p>
+</form>
+</body>
+</html>
+`
+
+// DownloadContent is the content served at DownloadPath, which links2
+// offers to save rather than display.
+var DownloadContent = bytes.Repeat([]byte("links2test download\n"), 4096)
+
+// A Site is a fixture site for the conformance suite served by an
+// httptest.Server: an index linking to a second page, a search form whose
+// results echo the query, and a download.
+type Site struct {
+	*httptest.Server
+}
+
+// NewSite starts a Site, which the caller should Close.
+func NewSite() *Site {
+	mux := http.NewServeMux()
+	page := func(path, src string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != path {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, src)
+		})
+	}
+	page(IndexPath, IndexSource)
+	page(SecondPath, secondSource)
+	page(FormPath, formSource)
+	mux.HandleFunc(SearchPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<html><head><title>Search results</title></head><body><p>You searched for %s.</p></body></html>\n",
+			html.EscapeString(r.FormValue("q")))
+	})
+	mux.HandleFunc(DownloadPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", `attachment; filename="download.bin"`)
+		w.Write(DownloadContent)
+	})
+	return &Site{httptest.NewServer(mux)}
+}
+
+// Page returns the URL of the page at path.
+func (s *Site) Page(path string) string { return s.URL + path }
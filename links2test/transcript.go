@@ -0,0 +1,145 @@
+// Package links2test records transcripts of links2 sessions and replays them
+// in place of links2, so code driving a links2.Browser can be tested on
+// machines without links2 installed.
+//
+// Record a session once against a real links2:
+//
+//	var r links2test.Recorder
+//	b.Open(r.Option())
+//	... drive the browser ...
+//	b.Close()
+//	r.Transcript().Save("testdata/session.jsonl")
+//
+// Then replay it in tests, which must call Main from TestMain:
+//
+//	func TestMain(m *testing.M) { links2test.Main(m) }
+//
+//	b.Open(links2.WithDriver(links2test.Replay("testdata/session.jsonl")))
+//
+// The replay writes the recorded output and checks that the same keys are
+// sent in the same order, so the code under test must drive the browser the
+// same way it did when recording.
+//
+// The package also provides the Conformance suite, which drives a real
+// links2 against the fixture Site to check an environment end to end.
+package links2test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ajzaff/links2"
+)
+
+// Event is a chunk of a session: keys sent to links2 or output read from it.
+type Event struct {
+	In  string `json:"in,omitempty"`
+	Out string `json:"out,omitempty"`
+}
+
+// Transcript is the events of a session in order.
+type Transcript []Event
+
+// Read parses a transcript of one JSON event per line.
+func Read(r io.Reader) (Transcript, error) {
+	var t Transcript
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("links2test: line %d: %v", line, err)
+		}
+		t = append(t, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Write writes t as one JSON event per line.
+func (t Transcript) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, e := range t {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Load reads the transcript file at path.
+func Load(path string) (Transcript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Save writes t to the file at path, replacing it.
+func (t Transcript) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := t.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Recorder records the transcript of a Browser session.
+// The zero value is ready to use.
+type Recorder struct {
+	mu sync.Mutex
+	t  Transcript
+}
+
+// Option returns the Open option recording the session to r.
+func (r *Recorder) Option() links2.Option {
+	return links2.WithTee(recorderOutput{r}, r.sent)
+}
+
+// Transcript returns the events recorded so far.
+func (r *Recorder) Transcript() Transcript {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append(Transcript(nil), r.t...)
+}
+
+func (r *Recorder) sent(keys string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.t); n > 0 && r.t[n-1].In != "" {
+		r.t[n-1].In += keys
+		return
+	}
+	r.t = append(r.t, Event{In: keys})
+}
+
+// recorderOutput records the output of links2.
+type recorderOutput struct{ r *Recorder }
+
+func (o recorderOutput) Write(p []byte) (int, error) {
+	r := o.r
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.t); n > 0 && r.t[n-1].Out != "" {
+		r.t[n-1].Out += string(p)
+	} else {
+		r.t = append(r.t, Event{Out: string(p)})
+	}
+	return len(p), nil
+}
@@ -0,0 +1,70 @@
+package links2
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/Netflix/go-expect"
+)
+
+// WithLogger logs browser operations to l. Navigations are logged at Info,
+// menus, key presses and expect matches at Debug, and the raw console output
+// read by expect at Debug with the "expect" source.
+// By default nothing is logged.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *options) error {
+		o.logger = l
+		return nil
+	}
+}
+
+// discardLogger is the logger of a Browser without WithLogger.
+var discardLogger = slog.New(discardHandler{})
+
+// discardHandler is a slog.Handler discarding all records.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// WithTee copies the raw output read from links2 to out and reports the keys
+// sent to links2 to sent, e.g. to record a transcript. Either may be nil.
+func WithTee(out io.Writer, sent func(keys string)) Option {
+	return func(o *options) error {
+		o.teeOut, o.teeSent = out, sent
+		return nil
+	}
+}
+
+// teeOpts returns the console options of WithTee.
+func teeOpts(o *options) []expect.ConsoleOpt {
+	var opts []expect.ConsoleOpt
+	if o.teeOut != nil {
+		opts = append(opts, expect.WithStdout(o.teeOut))
+	}
+	if sent := o.teeSent; sent != nil {
+		opts = append(opts, expect.WithSendObserver(func(msg string, n int, err error) {
+			if err == nil {
+				sent(msg)
+			}
+		}))
+	}
+	return opts
+}
+
+// consoleLogOpts returns the console options which log console activity to l.
+func consoleLogOpts(l *slog.Logger) []expect.ConsoleOpt {
+	return []expect.ConsoleOpt{
+		expect.WithLogger(slog.NewLogLogger(l.With("source", "expect").Handler(), slog.LevelDebug)),
+		expect.WithSendObserver(func(msg string, n int, err error) {
+			if err != nil {
+				l.Debug("send", "keys", msg, "n", n, "err", err)
+				return
+			}
+			l.Debug("send", "keys", msg)
+		}),
+	}
+}
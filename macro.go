@@ -0,0 +1,107 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Macro is a recorded sequence of key presses.
+type Macro []MacroStep
+
+// MacroStep is keys sent to links2 followed by an optional checkpoint.
+type MacroStep struct {
+	Keys string
+	// Expect, if set, is text which must appear on screen after Keys
+	// before the macro continues.
+	Expect string
+}
+
+// StartRecording starts recording the keys sent to links2 by every
+// operation, replacing any recording in progress.
+func (b *Browser) StartRecording() {
+	_, done := b.begin(context.Background())
+	defer done()
+	b.rec = &Macro{}
+}
+
+// Checkpoint adds a checkpoint to the recording: when the macro is played,
+// it waits for text to appear on screen before sending further keys.
+func (b *Browser) Checkpoint(text string) error {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.rec == nil {
+		return fmt.Errorf("checkpoint: not recording")
+	}
+	*b.rec = append(*b.rec, MacroStep{Expect: text})
+	return nil
+}
+
+// StopRecording stops recording and returns the recorded macro.
+func (b *Browser) StopRecording() Macro {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.rec == nil {
+		return nil
+	}
+	m := *b.rec
+	b.rec = nil
+	return m
+}
+
+// record is a send observer appending keys to the recording.
+func (b *Browser) record(keys string, n int, err error) {
+	if b.rec == nil || err != nil {
+		return
+	}
+	m := *b.rec
+	if len(m) > 0 && m[len(m)-1].Expect == "" {
+		m[len(m)-1].Keys += keys
+		return
+	}
+	*b.rec = append(m, MacroStep{Keys: keys})
+}
+
+// PlayMacro sends the keys of m, waiting at each checkpoint for its text to
+// appear on screen, bounded by the Navigate timeout.
+//
+// The keys are replayed as recorded, so menus are not closed first and the
+// state of the browser is re-synchronized by assuming the document view is
+// shown afterwards.
+func (b *Browser) PlayMacro(m Macro) error {
+	return b.PlayMacroContext(context.Background(), m)
+}
+
+// PlayMacroContext is like PlayMacro but bounds waits by ctx.
+func (b *Browser) PlayMacroContext(ctx context.Context, m Macro) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if b.c == nil {
+		return ErrNotStarted
+	}
+	if err := b.checkExited(); err != nil {
+		return err
+	}
+	defer func() {
+		b.s = stateIdle
+		b.menuName = ""
+	}()
+	for i, step := range m {
+		if step.Keys != "" {
+			if _, err := b.c.Send(step.Keys); err != nil {
+				return err
+			}
+		}
+		if step.Expect == "" {
+			continue
+		}
+		text := step.Expect
+		wctx, cancel := context.WithTimeout(ctx, b.timeouts.Navigate)
+		err := b.waitFor(wctx, func(screen string) bool { return strings.Contains(screen, text) })
+		cancel()
+		if err != nil {
+			return fmt.Errorf("play macro: step %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// WithMailto calls f with the address of mailto: links instead of following
+// them, since links2 would try to run an external mail program. It applies
+// to FollowLink, FollowLinkMatching and FollowLinkNumber; f is called while
+// the operation holds the Browser and must not use it.
+func WithMailto(f func(addr *url.URL)) Option {
+	return func(o *options) error {
+		o.mailto = f
+		return nil
+	}
+}
+
+// interceptMailto calls the function of WithMailto if link is a mailto:
+// link, reporting whether it did.
+func (b *Browser) interceptMailto(link Link) bool {
+	if b.opts == nil || b.opts.mailto == nil || !isMailto(link.URL) {
+		return false
+	}
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		u = &url.URL{Scheme: "mailto", Opaque: link.URL[len("mailto:"):]}
+	}
+	b.opts.mailto(u)
+	return true
+}
+
+// interceptSelectedMailto is interceptMailto for the selected link. It
+// only reads the link when WithMailto was given.
+func (b *Browser) interceptSelectedMailto(ctx context.Context) (bool, error) {
+	if b.opts == nil || b.opts.mailto == nil {
+		return false, nil
+	}
+	link, err := b.CurrentLinkContext(ctx)
+	if errors.Is(err, ErrNoLink) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return b.interceptMailto(link), nil
+}
+
+func isMailto(rawURL string) bool {
+	return len(rawURL) >= len("mailto:") && strings.EqualFold(rawURL[:len("mailto:")], "mailto:")
+}
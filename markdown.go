@@ -0,0 +1,297 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PageMarkdown returns the current document converted to Markdown.
+//
+// The document source is converted rather than the formatted text, so
+// headings, lists, links, emphasis, code and quotes keep their structure.
+// Relative links are resolved against the URL last loaded by Navigate.
+// Scripts, styles and forms are dropped.
+func (b *Browser) PageMarkdown() (string, error) {
+	return b.PageMarkdownContext(context.Background())
+}
+
+// PageMarkdownContext is like PageMarkdown but bounds waits by ctx.
+func (b *Browser) PageMarkdownContext(ctx context.Context) (string, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	var src bytes.Buffer
+	if err := b.writeSaved(&src, func(path string) error { return b.saveSource(ctx, path) }); err != nil {
+		return "", err
+	}
+	base, _ := url.Parse(b.lastURL)
+	return markdown(src.String(), base), nil
+}
+
+// skipTags are the elements whose content is left out of Markdown.
+var skipTags = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true,
+	"select": true, "textarea": true, "button": true, "svg": true,
+}
+
+// blockTags are the elements which start a new paragraph.
+var blockTags = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true,
+	"header": true, "footer": true, "nav": true, "aside": true, "table": true,
+	"tr": true, "form": true, "figure": true, "dl": true, "dt": true, "dd": true,
+	"ul": true, "ol": true,
+}
+
+// markdown converts an HTML document to Markdown, resolving links against
+// base if it's not nil.
+func markdown(src string, base *url.URL) string {
+	w := &mdWriter{base: base}
+	for _, tok := range tokenize(src) {
+		w.token(tok)
+	}
+	return w.String()
+}
+
+// mdList is an open list.
+type mdList struct {
+	ordered bool
+	n       int // n is the number of the last ordered item.
+}
+
+// mdWriter writes Markdown for a stream of tokens.
+type mdWriter struct {
+	sb     strings.Builder
+	base   *url.URL
+	breaks int  // breaks is the number of newlines to write before more content.
+	space  bool // space is a pending space between words.
+	skip   int  // skip is the depth of skipped elements.
+	pre    int
+	quote  int
+	// lastQuote is the quote depth of the last content written.
+	lastQuote int
+	lists     []mdList
+	links     []string // links are the targets of open links, "" for anchors.
+}
+
+func (w *mdWriter) token(tok markupToken) {
+	if w.skip > 0 {
+		switch {
+		case !skipTags[tok.tag]:
+		case tok.kind == startToken:
+			w.skip++
+		case tok.kind == endToken:
+			w.skip--
+		}
+		return
+	}
+	switch tok.kind {
+	case textToken:
+		w.text(tok.text)
+	case startToken:
+		w.start(tok)
+	case endToken:
+		w.end(tok.tag)
+	}
+}
+
+func (w *mdWriter) start(tok markupToken) {
+	switch tag := tok.tag; {
+	case skipTags[tag]:
+		w.skip++
+	case len(tag) == 2 && tag[0] == 'h' && '1' <= tag[1] && tag[1] <= '6':
+		w.block(2)
+		w.inline(strings.Repeat("#", int(tag[1]-'0')) + " ")
+	case tag == "ul" || tag == "ol":
+		w.block(w.listBreaks())
+		w.lists = append(w.lists, mdList{ordered: tag == "ol"})
+	case tag == "li":
+		w.block(1)
+		marker := "- "
+		if n := len(w.lists); n > 0 {
+			l := &w.lists[n-1]
+			if l.ordered {
+				l.n++
+				marker = strconv.Itoa(l.n) + ". "
+			}
+			marker = strings.Repeat("  ", n-1) + marker
+		}
+		w.inline(marker)
+	case tag == "blockquote":
+		w.block(2)
+		w.quote++
+	case tag == "pre":
+		w.block(2)
+		w.inline("```")
+		w.block(1)
+		w.pre++
+	case tag == "br":
+		w.block(1)
+	case tag == "hr":
+		w.block(2)
+		w.inline("---")
+		w.block(2)
+	case tag == "a":
+		href := tok.attrs["href"]
+		if href != "" {
+			w.inline("[")
+		}
+		w.links = append(w.links, w.resolve(href))
+	case tag == "img":
+		if src := tok.attrs["src"]; src != "" {
+			w.inline("![" + escapeMarkdown(tok.attrs["alt"]) + "](" + w.resolve(src) + ")")
+		}
+	case tag == "em" || tag == "i":
+		w.inline("*")
+	case tag == "strong" || tag == "b":
+		w.inline("**")
+	case tag == "code" && w.pre == 0:
+		w.inline("`")
+	case tag == "td" || tag == "th":
+		w.inline(" ")
+	case blockTags[tag]:
+		w.block(2)
+	}
+}
+
+func (w *mdWriter) end(tag string) {
+	switch {
+	case len(tag) == 2 && tag[0] == 'h' && '1' <= tag[1] && tag[1] <= '6':
+		w.block(2)
+	case tag == "ul" || tag == "ol":
+		if n := len(w.lists); n > 0 {
+			w.lists = w.lists[:n-1]
+		}
+		w.block(w.listBreaks())
+	case tag == "blockquote":
+		w.block(2)
+		w.quote = max(w.quote-1, 0)
+	case tag == "pre":
+		if w.pre > 0 {
+			w.pre--
+			w.block(1)
+			w.inline("```")
+			w.block(2)
+		}
+	case tag == "a":
+		if n := len(w.links); n > 0 {
+			if href := w.links[n-1]; href != "" {
+				w.close("](" + href + ")")
+			}
+			w.links = w.links[:n-1]
+		}
+	case tag == "em" || tag == "i":
+		w.close("*")
+	case tag == "strong" || tag == "b":
+		w.close("**")
+	case tag == "code" && w.pre == 0:
+		w.close("`")
+	case blockTags[tag]:
+		w.block(2)
+	}
+}
+
+// block ends the current line and, for n of 2, the paragraph.
+func (w *mdWriter) block(n int) {
+	w.breaks = max(w.breaks, n)
+	w.space = false
+}
+
+// listBreaks is the number of line breaks around a list, which ends a
+// paragraph only at the outermost level.
+func (w *mdWriter) listBreaks() int {
+	if len(w.lists) == 0 {
+		return 2
+	}
+	return 1
+}
+
+func (w *mdWriter) resolve(href string) string {
+	if href == "" || w.base == nil {
+		return href
+	}
+	u, err := w.base.Parse(href)
+	if err != nil {
+		return href
+	}
+	return u.String()
+}
+
+// text writes a run of document text.
+func (w *mdWriter) text(s string) {
+	if w.pre > 0 {
+		lines := strings.Split(s, "\n")
+		for i, line := range lines {
+			if i > 0 {
+				w.breaks = max(w.breaks, 1)
+			}
+			if line != "" {
+				w.write(line)
+			}
+		}
+		return
+	}
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		if s != "" {
+			w.space = true
+		}
+		return
+	}
+	if isSpace(s[0]) {
+		w.space = true
+	}
+	w.inline(escapeMarkdown(strings.Join(fields, " ")))
+	w.space = isSpace(s[len(s)-1])
+}
+
+// inline writes s after any pending space.
+func (w *mdWriter) inline(s string) {
+	if w.space && w.breaks == 0 && w.sb.Len() > 0 {
+		w.write(" ")
+	}
+	w.space = false
+	w.write(s)
+}
+
+// close writes a closing marker which binds to the preceding word.
+func (w *mdWriter) close(s string) {
+	space := w.space
+	w.space = false
+	w.write(s)
+	w.space = space
+}
+
+// write writes s after any pending line breaks.
+func (w *mdWriter) write(s string) {
+	if w.breaks > 0 && w.sb.Len() > 0 {
+		// Blank lines only continue a quote which continues.
+		blank := strings.TrimSpace(strings.Repeat("> ", min(w.quote, w.lastQuote)))
+		for i := 0; i < w.breaks; i++ {
+			w.sb.WriteString("\n")
+			if i < w.breaks-1 {
+				w.sb.WriteString(blank)
+			}
+		}
+		w.sb.WriteString(strings.Repeat("> ", w.quote))
+	} else if w.sb.Len() == 0 {
+		w.sb.WriteString(strings.Repeat("> ", w.quote))
+	}
+	w.breaks = 0
+	w.sb.WriteString(s)
+	w.lastQuote = w.quote
+}
+
+var trailingSpace = regexp.MustCompile(`[ \t]+\n`)
+
+func (w *mdWriter) String() string {
+	s := trailingSpace.ReplaceAllString(w.sb.String(), "\n")
+	return strings.TrimSpace(s) + "\n"
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+
+// escapeMarkdown escapes the characters of s which Markdown treats as markup.
+func escapeMarkdown(s string) string { return markdownEscaper.Replace(s) }
@@ -0,0 +1,41 @@
+package links2
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	base, _ := url.Parse("http://example.com/dir/page.html")
+	tests := []struct {
+		name, src, want string
+	}{
+		{"paragraphs", "<p>One  two\nthree</p><p>Four</p>", "One two three\n\nFour\n"},
+		{"headings", "<h1>Title</h1><p>Text</p><h3>Sub</h3>", "# Title\n\nText\n\n### Sub\n"},
+		{"emphasis", "<p>An <em>em</em>, <b>bold </b>and <code>x()</code>.</p>", "An *em*, **bold** and `x()`.\n"},
+		{"escapes", "<p>a*b_c [d] `e` \\</p>", "a\\*b\\_c \\[d\\] \\`e\\` \\\\\n"},
+		{"links", `<p>See <a href="other.html">the other</a> and <a href="/top">top</a>.</p>`, "See [the other](http://example.com/dir/other.html) and [top](http://example.com/top).\n"},
+		{"anchor", `<a name="x">here</a>`, "here\n"},
+		{"image", `<img src="a.png" alt="An [image]">`, "![An \\[image\\]](http://example.com/dir/a.png)\n"},
+		{"unordered list", "<ul><li>a</li><li>b</li></ul>", "- a\n- b\n"},
+		{"ordered list", "<ol><li>a<li>b</ol><p>after</p>", "1. a\n2. b\n\nafter\n"},
+		{"nested list", "<ul><li>a<ol><li>x</li><li>y</li></ol></li><li>b</li></ul>", "- a\n  1. x\n  2. y\n- b\n"},
+		{"quote", "<blockquote><p>q1</p><p>q2</p></blockquote><p>after</p>", "> q1\n>\n> q2\n\nafter\n"},
+		{"pre", "<pre>line 1\n  *line 2*\n</pre>", "```\nline 1\n  *line 2*\n```\n"},
+		{"breaks", "a<br>b<hr>c", "a\nb\n\n---\n\nc\n"},
+		{"skipped", "<head><title>T</title></head><script>x()</script><p>kept</p><style>p{}</style>", "kept\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := markdown(tc.src, base); got != tc.want {
+				t.Errorf("markdown(%q) =\n%q\nwant\n%q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownNoBase(t *testing.T) {
+	if got, want := markdown(`<a href="rel.html">r</a>`, nil), "[r](rel.html)\n"; got != want {
+		t.Errorf("markdown = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+package links2
+
+import (
+	"html"
+	"strings"
+)
+
+// tokenKind is the kind of a markupToken.
+type tokenKind int
+
+const (
+	textToken tokenKind = iota
+	startToken
+	endToken
+)
+
+// markupToken is a tag or run of text of an HTML document.
+type markupToken struct {
+	kind  tokenKind
+	tag   string            // tag is the lower case tag name of start and end tokens.
+	attrs map[string]string // attrs are the attributes of start tokens.
+	text  string            // text is the unescaped text of text tokens.
+}
+
+// rawTextTags are the elements whose content is not markup.
+var rawTextTags = map[string]bool{"script": true, "style": true, "textarea": true, "title": true}
+
+// tokenize splits an HTML document into tags and text. It's a lenient
+// tokenizer which doesn't build a tree: comments, doctypes and processing
+// instructions are dropped, and unclosed or stray tags are left as they are.
+func tokenize(src string) []markupToken {
+	var toks []markupToken
+	text := func(s string) {
+		if s != "" {
+			toks = append(toks, markupToken{kind: textToken, text: html.UnescapeString(s)})
+		}
+	}
+	for len(src) > 0 {
+		i := strings.IndexByte(src, '<')
+		if i < 0 {
+			text(src)
+			break
+		}
+		text(src[:i])
+		src = src[i:]
+		switch {
+		case strings.HasPrefix(src, "<!--"):
+			if j := strings.Index(src, "-->"); j >= 0 {
+				src = src[j+3:]
+			} else {
+				src = ""
+			}
+			continue
+		case strings.HasPrefix(src, "<!"), strings.HasPrefix(src, "<?"):
+			if j := strings.IndexByte(src, '>'); j >= 0 {
+				src = src[j+1:]
+			} else {
+				src = ""
+			}
+			continue
+		}
+		tok, n := parseTag(src)
+		if n == 0 {
+			text("<")
+			src = src[1:]
+			continue
+		}
+		toks = append(toks, tok)
+		src = src[n:]
+		if tok.kind == startToken && rawTextTags[tok.tag] {
+			end := strings.Index(strings.ToLower(src), "</"+tok.tag)
+			if end < 0 {
+				end = len(src)
+			}
+			text(src[:end])
+			src = src[end:]
+		}
+	}
+	return toks
+}
+
+// parseTag parses the start or end tag at the start of s, returning its
+// length or 0 if s doesn't start with a tag.
+func parseTag(s string) (markupToken, int) {
+	tok := markupToken{kind: startToken}
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		tok.kind = endToken
+		i++
+	}
+	j := i
+	for j < len(s) && isTagNameByte(s[j]) {
+		j++
+	}
+	if j == i || !isLetter(s[i]) {
+		return markupToken{}, 0
+	}
+	tok.tag = strings.ToLower(s[i:j])
+	tok.attrs = make(map[string]string)
+	for i = j; i < len(s); {
+		switch c := s[i]; {
+		case c == '>':
+			return tok, i + 1
+		case c == '/' || isSpace(c):
+			i++
+		default:
+			j := i
+			for j < len(s) && s[j] != '=' && s[j] != '>' && s[j] != '/' && !isSpace(s[j]) {
+				j++
+			}
+			name := strings.ToLower(s[i:j])
+			i = j
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			if i >= len(s) || s[i] != '=' {
+				tok.attrs[name] = ""
+				continue
+			}
+			i++
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			var value string
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				q := s[i]
+				j := strings.IndexByte(s[i+1:], q)
+				if j < 0 {
+					return markupToken{}, 0
+				}
+				value = s[i+1 : i+1+j]
+				i += j + 2
+			} else {
+				j := i
+				for j < len(s) && s[j] != '>' && !isSpace(s[j]) {
+					j++
+				}
+				value = s[i:j]
+				i = j
+			}
+			tok.attrs[name] = html.UnescapeString(value)
+		}
+	}
+	return markupToken{}, 0
+}
+
+func isLetter(c byte) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' }
+
+func isTagNameByte(c byte) bool { return isLetter(c) || '0' <= c && c <= '9' || c == '-' }
+
+func isSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' }
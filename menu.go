@@ -0,0 +1,74 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxMenuItems bounds how many items OpenMenu visits looking for a label.
+const maxMenuItems = 256
+
+// OpenMenu opens the dropdown menu and walks it by label, e.g.
+//
+//	b.OpenMenu("Setup", "Terminal options")
+//
+// Each label is matched against the start of the highlighted item as the
+// selection moves, so hotkey hints after the label are ignored. The last
+// item is activated, which may open a dialog; it is closed by the next
+// operation like any other menu.
+func (b *Browser) OpenMenu(path ...string) error {
+	return b.OpenMenuContext(context.Background(), path...)
+}
+
+// OpenMenuContext is like OpenMenu but bounds waits by ctx.
+func (b *Browser) OpenMenuContext(ctx context.Context, path ...string) error {
+	_, done := b.begin(ctx)
+	defer done()
+	if len(path) == 0 {
+		return fmt.Errorf("open menu: empty path")
+	}
+	if err := b.openDropDownMenu(); err != nil {
+		return err
+	}
+	b.menuName = strings.Join(path, "/")
+	// The menu bar is the top row; items are below it.
+	if err := b.selectMenuItem(0, path[0], ActionNextMenu); err != nil {
+		b.closeMenu()
+		return fmt.Errorf("open menu: %w", err)
+	}
+	for _, label := range path[1:] {
+		if err := b.press(ActionSelect); err != nil {
+			return err
+		}
+		if err := b.selectMenuItem(1, label, ActionNextItem); err != nil {
+			b.closeMenu()
+			return fmt.Errorf("open menu: %w", err)
+		}
+	}
+	return b.press(ActionSelect)
+}
+
+// selectMenuItem moves the menu selection (or dialog focus) with action next
+// until the highlighted item at or below row minRow starts with label.
+func (b *Browser) selectMenuItem(minRow int, label string, next Action) error {
+	var first string
+	for i := 0; i < maxMenuItems; i++ {
+		if _, err := b.drain(); err != nil {
+			return err
+		}
+		_, text := b.scr.highlight(minRow)
+		if strings.HasPrefix(text, b.tr(label)) {
+			return nil
+		}
+		if i == 0 {
+			first = text
+		} else if text == first {
+			break // Wrapped around.
+		}
+		if err := b.press(next); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("no item %q", label)
+}
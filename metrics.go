@@ -0,0 +1,63 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// Metrics receives measurements of browser operations, e.g. to export them
+// to Prometheus. Methods are called while an operation is in progress and
+// must not block or use the Browser.
+type Metrics interface {
+	// Navigation is called when a navigation finishes with its result and
+	// error, if any. Per phase latency is given by res.PhaseDuration and
+	// the kind of error by ErrorKind.
+	Navigation(res NavigateResult, err error)
+	// Timeout is called when links2 did not draw an expected pattern in time.
+	Timeout()
+}
+
+// WithMetrics reports measurements of browser operations to m.
+func WithMetrics(m Metrics) Option {
+	return func(o *options) error {
+		o.metrics = m
+		return nil
+	}
+}
+
+// ErrorKind returns a short label for the kind of err suitable for metrics,
+// e.g. "host_not_found". It returns "" for a nil error and "other" for
+// errors of an unknown kind.
+func ErrorKind(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrHostNotFound):
+		return "host_not_found"
+	case errors.Is(err, ErrSSLFailure):
+		return "ssl_failure"
+	case errors.Is(err, ErrNoSuchFile):
+		return "no_such_file"
+	case errors.Is(err, ErrAuthRequired):
+		return "auth_required"
+	case errors.Is(err, ErrLoading):
+		return "loading"
+	case errors.Is(err, ErrTimeout), errors.Is(err, os.ErrDeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	case errors.Is(err, ErrProcessExited):
+		return "process_exited"
+	default:
+		return "other"
+	}
+}
+
+// metrics returns the Metrics of WithMetrics or nil.
+func (b *Browser) metrics() Metrics {
+	if b.opts == nil {
+		return nil
+	}
+	return b.opts.metrics
+}
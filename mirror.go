@@ -0,0 +1,163 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Files written by MirrorPage.
+const (
+	mirrorSource = "index.html"
+	mirrorText   = "index.txt"
+)
+
+// mirrorPoll is how often MirrorPage checks on its downloads.
+const mirrorPoll = 250 * time.Millisecond
+
+// MirrorPage saves a snapshot of the current document to the directory dir,
+// creating it if needed: the source as index.html, the formatted document as
+// index.txt, and the images and stylesheets the source refers to, named
+// after their URLs. References to the saved files in index.html are
+// rewritten to the local copies.
+//
+// Resources which fail to download are skipped but reported by the error,
+// which joins their errors, after the rest of the snapshot is saved.
+func (b *Browser) MirrorPage(dir string) error {
+	return b.MirrorPageContext(context.Background(), dir)
+}
+
+// MirrorPageContext is like MirrorPage but bounds waits by ctx.
+func (b *Browser) MirrorPageContext(ctx context.Context, dir string) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	dir = b.resolvePath(dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	var src bytes.Buffer
+	if err := b.writeSaved(&src, func(path string) error { return b.saveSource(ctx, path) }); err != nil {
+		return err
+	}
+	doc, err := b.formattedDocument(ctx)
+	if err != nil {
+		return err
+	}
+	base, _ := url.Parse(b.lastURL)
+	var (
+		errs      []error
+		downloads []*Download
+		local     = make(map[string]string) // local maps references to file names.
+		names     = map[string]bool{mirrorSource: true, mirrorText: true}
+		files     = make(map[string]string) // files maps URLs to file names.
+	)
+	for _, ref := range resources(src.String()) {
+		u, err := url.Parse(ref)
+		if err != nil || u.Scheme == "data" {
+			continue
+		}
+		if base != nil {
+			u = base.ResolveReference(u)
+		}
+		abs := u.String()
+		name, ok := files[abs]
+		if !ok {
+			name = downloadName(abs, names)
+			d, err := b.fetchResource(ctx, abs, filepath.Join(dir, name))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if d != nil {
+				downloads = append(downloads, d)
+			}
+			files[abs] = name
+		}
+		local[ref] = name
+	}
+	for _, d := range downloads {
+		if err := d.Wait(ctx, mirrorPoll); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, mirrorSource), []byte(rewriteRefs(src.String(), local)), 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, mirrorText), []byte(doc), 0o644); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// fetchResource saves the document at rawURL to path, returning its
+// Download if links2 saves it in the background. Documents links2 displays,
+// like stylesheets, are saved from the source view instead, after which the
+// previous document is shown again.
+func (b *Browser) fetchResource(ctx context.Context, rawURL, path string) (*Download, error) {
+	lastURL := b.lastURL
+	b.saveAs = path
+	defer func() { b.saveAs, b.lastURL = "", lastURL }()
+	res, err := b.NavigateContext(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if res.Download != nil {
+		return res.Download, nil
+	}
+	err = b.saveSource(ctx, path)
+	if err1 := b.sendLoad(ActionBack); err == nil {
+		err = err1
+	}
+	if err != nil {
+		return nil, fmt.Errorf("save %s: %w", rawURL, err)
+	}
+	return nil, nil
+}
+
+// resources returns the references to images and stylesheets of an HTML
+// document in document order.
+func resources(src string) []string {
+	var refs []string
+	for _, tok := range tokenize(src) {
+		if tok.kind != startToken {
+			continue
+		}
+		var ref string
+		switch tok.tag {
+		case "img":
+			ref = tok.attrs["src"]
+		case "link":
+			if rel := strings.Fields(strings.ToLower(tok.attrs["rel"])); slices.Contains(rel, "stylesheet") || slices.Contains(rel, "icon") {
+				ref = tok.attrs["href"]
+			}
+		}
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// rewriteRefs replaces the quoted attribute values of src which are keys of
+// local with the values. Values are matched as written, including with
+// characters escaped as entities.
+func rewriteRefs(src string, local map[string]string) string {
+	var oldnew []string
+	for ref, name := range local {
+		for _, v := range []string{ref, html.EscapeString(ref)} {
+			oldnew = append(oldnew, `"`+v+`"`, `"`+name+`"`, `'`+v+`'`, `'`+name+`'`)
+		}
+	}
+	if len(oldnew) == 0 {
+		return src
+	}
+	return strings.NewReplacer(oldnew...).Replace(src)
+}
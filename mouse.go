@@ -0,0 +1,54 @@
+package links2
+
+import "fmt"
+
+// Wheel is the direction of a mouse wheel scroll.
+type Wheel int
+
+const (
+	WheelUp Wheel = iota
+	WheelDown
+)
+
+// Mouse buttons of the xterm X10 mouse protocol.
+const (
+	mouseLeft     = 0
+	mouseRelease  = 3
+	mouseWheelUp  = 64
+	mouseWheelDwn = 65
+)
+
+// maxMouseCoord is the largest 0-based coordinate the X10 encoding can carry.
+const maxMouseCoord = 255 - 33
+
+// mouseSeq encodes a mouse event for button at column x and row y (0-based).
+func mouseSeq(button, x, y int) (string, error) {
+	if x < 0 || y < 0 || x > maxMouseCoord || y > maxMouseCoord {
+		return "", fmt.Errorf("mouse position out of range: (%d, %d)", x, y)
+	}
+	return string([]byte{'\033', '[', 'M', byte(32 + button), byte(33 + x), byte(33 + y)}), nil
+}
+
+// Click clicks the left mouse button at column x and row y of the screen,
+// after closing any open menu. Positions are 0-based like Cell.
+func (b *Browser) Click(x, y int) error {
+	press, err := mouseSeq(mouseLeft, x, y)
+	if err != nil {
+		return err
+	}
+	release, _ := mouseSeq(mouseRelease, x, y)
+	return b.send(press + release)
+}
+
+// Scroll turns the mouse wheel one step in direction dir at column x and row y.
+func (b *Browser) Scroll(x, y int, dir Wheel) error {
+	button := mouseWheelUp
+	if dir == WheelDown {
+		button = mouseWheelDwn
+	}
+	seq, err := mouseSeq(button, x, y)
+	if err != nil {
+		return err
+	}
+	return b.send(seq)
+}
@@ -0,0 +1,421 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Netflix/go-expect"
+)
+
+// Phase is a page load phase reported in the links2 status bar.
+type Phase int
+
+const (
+	PhaseLookup       Phase = iota // PhaseLookup the host is being looked up.
+	PhaseConnect                   // PhaseConnect a connection is being made.
+	PhaseSSLNegotiate              // PhaseSSLNegotiate SSL is being negotiated.
+	PhaseRequestSent               // PhaseRequestSent the request was sent and we're waiting for the response.
+	PhaseFormatting                // PhaseFormatting the document is being formatted.
+)
+
+var phaseNames = [...]string{
+	PhaseLookup:       "lookup",
+	PhaseConnect:      "connect",
+	PhaseSSLNegotiate: "ssl negotiate",
+	PhaseRequestSent:  "request sent",
+	PhaseFormatting:   "formatting",
+}
+
+func (p Phase) String() string {
+	if p < 0 || int(p) >= len(phaseNames) {
+		return fmt.Sprintf("Phase(%d)", int(p))
+	}
+	return phaseNames[p]
+}
+
+// phasePatterns maps status bar messages to the phase they indicate.
+var phasePatterns = [...]string{
+	PhaseLookup:       lookupHost,
+	PhaseConnect:      makeConnection,
+	PhaseSSLNegotiate: sslNegotiate,
+	PhaseRequestSent:  requestSent,
+	PhaseFormatting:   formatDocument,
+}
+
+// PhaseEvent records when a load phase was first observed.
+type PhaseEvent struct {
+	Phase Phase
+	Time  time.Time
+}
+
+// NavigateResult describes a completed call to Navigate.
+type NavigateResult struct {
+	URL    *url.URL     // URL is the URL entered in the Go to URL dialog.
+	Start  time.Time    // Start is when the URL was entered.
+	End    time.Time    // End is when the page load finished or failed.
+	Phases []PhaseEvent // Phases are the load phases observed in order.
+	// Download is the download of a document saved by WithContentPolicy.
+	Download *Download
+	// Redirects is the number of redirects followed, counted from the
+	// connection phases seen again after a request was sent. A redirect on a
+	// kept-alive connection shows no new phases and is not counted.
+	Redirects int
+	// Location is the final URL of redirected loads, as reported by the Info
+	// dialog, or nil. Links2 doesn't show the intermediate URLs.
+	Location *url.URL
+	// Attempts is the number of times the URL was tried, see WithRetry.
+	Attempts int
+}
+
+// Duration returns the total time spent loading the page.
+func (r NavigateResult) Duration() time.Duration { return r.End.Sub(r.Start) }
+
+// PhaseDuration returns the time spent in phase p, up to the next phase or the end
+// of the load. It returns false if p was not observed.
+func (r NavigateResult) PhaseDuration(p Phase) (time.Duration, bool) {
+	for i, e := range r.Phases {
+		if e.Phase != p {
+			continue
+		}
+		end := r.End
+		if i+1 < len(r.Phases) {
+			end = r.Phases[i+1].Time
+		}
+		return end.Sub(e.Time), true
+	}
+	return 0, false
+}
+
+// Navigate the browser to the given URL.
+//
+// If links2 fails to load the page, the error wraps one of ErrHostNotFound,
+// ErrNoSuchFile, ErrSSLFailure, or ErrLoading. The result is valid either way.
+// A URL without a host or scheme is a local file, except a bare
+// ftp.example.com/path which is an FTP URL; directory listings can be read
+// with DirEntries. Other schemes without a host, like data:, are kept.
+// A URL fragment is followed with GoToAnchor once the page is loaded.
+// Navigate first waits on the Limiter of WithLimiter for the host, if any.
+// Redirects are reported by the Redirects and Location fields of the result.
+// Failed loads are retried as configured by WithRetry.
+func (b *Browser) Navigate(rawURL string) (NavigateResult, error) {
+	return b.NavigateContext(context.Background(), rawURL)
+}
+
+// NavigateContext is like Navigate but bounds waits by ctx.
+func (b *Browser) NavigateContext(ctx context.Context, rawURL string) (NavigateResult, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	ctx, span := b.startSpan(ctx, "links2.Navigate")
+	if u, err := url.Parse(rawURL); err == nil {
+		span.SetAttribute("url", u.Redacted())
+	}
+	res, err := b.navigateRetry(rawURL)
+	b.tracePhases(ctx, res)
+	span.End(time.Now(), err)
+	return res, err
+}
+
+// NavigateRelative resolves ref against the URL of the current document, as
+// reported by CurrentURL, and navigates to the result. It is useful for the
+// relative hrefs found in page source.
+func (b *Browser) NavigateRelative(ref string) (NavigateResult, error) {
+	return b.NavigateRelativeContext(context.Background(), ref)
+}
+
+// NavigateRelativeContext is like NavigateRelative but bounds waits by ctx.
+func (b *Browser) NavigateRelativeContext(ctx context.Context, ref string) (NavigateResult, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	r, err := url.Parse(ref)
+	if err != nil {
+		return NavigateResult{}, fmt.Errorf("navigate relative: %w", err)
+	}
+	base, err := b.CurrentURLContext(ctx)
+	if err != nil {
+		return NavigateResult{}, fmt.Errorf("navigate relative %s: %w", ref, err)
+	}
+	return b.NavigateContext(ctx, base.ResolveReference(r).String())
+}
+
+func (b *Browser) navigate(rawURL string) (NavigateResult, error) {
+	// This serves to sanitize URL to ensure it has no terminal commands within.
+	if err := checkInput(rawURL); err != nil {
+		return NavigateResult{}, fmt.Errorf("navigate: %w", err)
+	}
+	u, err := parseNavigateURL(rawURL)
+	if err != nil {
+		return NavigateResult{}, err
+	}
+	if u.Scheme == "file" {
+		if err := b.checkAnonymous("navigate " + u.Redacted()); err != nil {
+			return NavigateResult{}, err
+		}
+	}
+	fragment := u.Fragment
+	u.Fragment, u.RawFragment = "", ""
+	// Logs, events and errors leave out any password.
+	redacted := u.Redacted()
+	if err := b.waitLimit(u.Hostname()); err != nil {
+		return NavigateResult{}, fmt.Errorf("navigate %s: %w", redacted, err)
+	}
+	// Check the URL as typed too: normalization may add a scheme or path but
+	// leaves non-ASCII hosts unescaped.
+	typed := u.String()
+	if err := checkInput(typed); err != nil {
+		return NavigateResult{}, fmt.Errorf("navigate %s: %w", redacted, err)
+	}
+	// Open GoTo menu.
+	if err := b.perform(ActionGoTo); err != nil {
+		return NavigateResult{}, err
+	}
+	if err := b.expectGoToMenu(); err != nil {
+		return NavigateResult{}, err
+	}
+
+	// Hack? Ending with Esc (menu) and calling expectMenu is
+	// the easiest way to determine when the page load finishes.
+	res := NavigateResult{URL: u, Start: time.Now()}
+	b.events.emit(Event{Kind: EventNavigateStart, Time: res.Start, URL: redacted})
+	err = b.expectLoad(typed+"\n\033", &res)
+	res.End = time.Now()
+	if err != nil {
+		err = fmt.Errorf("navigate %s: %w", redacted, err)
+	}
+	b.events.emit(Event{Kind: EventNavigateFinish, Time: res.End, URL: redacted, Err: err})
+	if m := b.metrics(); m != nil {
+		m.Navigation(res, err)
+	}
+	if err != nil {
+		b.log.Info("navigate", "url", redacted, "duration", res.Duration(), "err", err)
+		return res, err
+	}
+	if res.Redirects > 0 {
+		if err := b.redirectLocation(&res); err != nil {
+			return res, fmt.Errorf("navigate %s: %w", redacted, err)
+		}
+	}
+	b.log.Info("navigate", "url", redacted, "duration", res.Duration(), "phases", len(res.Phases), "redirects", res.Redirects)
+	b.lastURL = u.String()
+	if fragment != "" {
+		if err := b.goToAnchor(fragment); err != nil {
+			return res, fmt.Errorf("navigate %s: %w", redacted, err)
+		}
+	}
+	return res, nil
+}
+
+// hostPattern matches a URL without a scheme which starts with a host
+// links2 would fetch: a bracketed IPv6 literal, a host with userinfo or a
+// host with a port.
+var hostPattern = regexp.MustCompile(`^(\[[0-9A-Fa-f:.]+(%25[\w.~-]+)?\](:\d+)?|[^/?#@\s]+@[^/?#@\s]+|[A-Za-z0-9.-]+:\d+)([/?#]|$)`)
+
+// schemePattern matches the scheme of a URL without an authority, like
+// mailto:user@host.
+var schemePattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]*):`)
+
+// opaqueSchemes are the schemes of URLs links2 opens without an authority,
+// which must not be mistaken for the userinfo of an http URL.
+var opaqueSchemes = map[string]bool{
+	"about":      true,
+	"data":       true,
+	"javascript": true,
+	"mailto":     true,
+	"news":       true,
+	"telnet":     true,
+	"tn3270":     true,
+}
+
+// parseNavigateURL parses a URL given to Navigate, fixing up the scheme.
+// URLs with a scheme are kept, including their IPv6 hosts, ports and
+// userinfo. Without a scheme, a URL starting with a host like [::1]:8080,
+// user@host or host:8080 is an http URL, ftp.example.com/path is an ftp URL
+// and anything else is a local file, relative to the working directory.
+func parseNavigateURL(rawURL string) (*url.URL, error) {
+	switch {
+	case strings.Contains(rawURL, "://"), strings.HasPrefix(rawURL, "/"):
+	case opaqueScheme(rawURL):
+	case hostPattern.MatchString(rawURL):
+		rawURL = "http://" + rawURL
+	case strings.HasPrefix(rawURL, "ftp."):
+		// A bare ftp.example.com/pub is an FTP site as links2 treats it.
+		rawURL = "ftp://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" && (u.Scheme == "" || u.Scheme == "file") {
+		if u.Scheme == "" && !path.IsAbs(u.Path) {
+			// Relative to the working directory, as links2 resolves it.
+			abs, err := filepath.Abs(filepath.FromSlash(u.Path))
+			if err != nil {
+				return nil, err
+			}
+			u.Path, u.RawPath = filepath.ToSlash(abs), ""
+		}
+		u.Scheme = "file"
+	}
+	return u, nil
+}
+
+// opaqueScheme reports whether rawURL starts with one of opaqueSchemes.
+func opaqueScheme(rawURL string) bool {
+	m := schemePattern.FindStringSubmatch(rawURL)
+	return m != nil && opaqueSchemes[strings.ToLower(m[1])]
+}
+
+// GoToAnchor scrolls the current document to the anchor with the given name
+// or id, by going to the URL "#name" relative to it.
+func (b *Browser) GoToAnchor(name string) error {
+	return b.GoToAnchorContext(context.Background(), name)
+}
+
+// GoToAnchorContext is like GoToAnchor but bounds waits by ctx.
+func (b *Browser) GoToAnchorContext(ctx context.Context, name string) error {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.goToAnchor(name)
+}
+
+func (b *Browser) goToAnchor(name string) error {
+	if err := checkInput(name); err != nil {
+		return err
+	}
+	if err := b.perform(ActionGoTo); err != nil {
+		return err
+	}
+	if err := b.expectGoToMenu(); err != nil {
+		return err
+	}
+	b.c.Send("#" + url.PathEscape(name) + "\n")
+	_, err := b.drain()
+	return err
+}
+
+// expectLoad sends keys starting a page load and waits for it to finish as
+// expectLoaded does. The load may be aborted by StopLoading.
+func (b *Browser) expectLoad(keys string, res *NavigateResult) error {
+	prev := b.ctx
+	parent := prev
+	if parent == nil {
+		parent = context.Background()
+	}
+	b.ctx = b.startLoad(parent)
+	defer func() { b.ctx = prev }()
+	b.c.Send(keys)
+	err := b.expectLoaded(res)
+	if err == nil {
+		// A new document is shown rendered.
+		b.viewSource = false
+	}
+	if b.finishLoad() {
+		if err == nil {
+			// The dropdown menu opened once links2 stopped.
+			return ErrNavigationAborted
+		}
+		return fmt.Errorf("%w: %w", ErrNavigationAborted, err)
+	}
+	return err
+}
+
+// expectLoaded waits for the dropdown menu which signals the page load
+// finished, or for an error dialog, recording load phases in res as they're
+// observed. Prompts interrupting the load are answered. Error dialogs are
+// left open for closeMenu.
+func (b *Browser) expectLoaded(res *NavigateResult) error {
+	patterns := make([]string, len(phasePatterns), len(phasePatterns)+2)
+	for p, pattern := range phasePatterns {
+		patterns[p] = b.tr(pattern)
+	}
+	ok := b.tr(okButton)
+	prompts := b.prompts()
+	for _, p := range prompts {
+		patterns = append(patterns, p.pattern)
+	}
+	opts := []expect.ExpectOpt{b.match(dropdownMenu, errorText), expect.String(patterns...)}
+	progress := b.progressReporter()
+	if progress != nil {
+		opts = append(opts, expect.Regexp(receivingStatus))
+	}
+	for {
+		buf, err := b.expect(b.timeouts.Navigate, opts...)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		switch {
+		case b.matched(buf, dropdownMenu):
+			b.s = stateMenu
+			return nil
+		case b.matched(buf, errorText):
+			b.s = stateMenu
+			b.menuName = menuError
+			raw, err := b.expectString(okButton)
+			if err != nil {
+				return err
+			}
+			msg := strings.Join(dialogLines(strings.TrimSuffix(raw, ok)), " ")
+			b.events.emit(Event{Kind: EventErrorDialog, URL: res.urlString(), Message: msg})
+			return fmt.Errorf("%w: %s", b.loadingError(raw), msg)
+		}
+		for _, p := range prompts {
+			if strings.HasSuffix(buf, p.pattern) {
+				if err := p.answer(res); err != nil {
+					return err
+				}
+			}
+		}
+		for p, pattern := range patterns[:len(phasePatterns)] {
+			if strings.HasSuffix(buf, pattern) {
+				res.observe(Phase(p), now)
+			}
+		}
+		if progress != nil {
+			progress.update(buf, res)
+		}
+	}
+}
+
+// redirectLocation sets res.Location from the Info dialog when the loaded
+// document is not at res.URL.
+func (b *Browser) redirectLocation(res *NavigateResult) error {
+	info, _, err := b.documentInfo()
+	if err != nil {
+		return err
+	}
+	loc, err := url.Parse(info.URL)
+	if err != nil {
+		return err
+	}
+	if loc.String() != res.urlString() {
+		res.Location = loc
+	}
+	return nil
+}
+
+// observe records phase p unless it's the phase most recently observed.
+// The status bar is redrawn often during a phase. A connection phase after
+// the request was sent starts a redirect.
+func (r *NavigateResult) observe(p Phase, t time.Time) {
+	n := len(r.Phases)
+	if n > 0 && r.Phases[n-1].Phase == p {
+		return
+	}
+	if n > 0 && r.Phases[n-1].Phase == PhaseRequestSent && p < PhaseRequestSent {
+		r.Redirects++
+	}
+	r.Phases = append(r.Phases, PhaseEvent{Phase: p, Time: t})
+}
+
+func (r *NavigateResult) urlString() string {
+	if r.URL == nil {
+		return ""
+	}
+	return r.URL.String()
+}
@@ -0,0 +1,219 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/ajzaff/links2/config"
+)
+
+// Labels of the Setup→Network options dialog.
+const labelAsyncDNS = "Async DNS lookup"
+
+// NetworkSettings are the settings of the Setup→Network options dialog,
+// which bound how long Navigate takes to fail. Zero numeric fields are left
+// unchanged.
+type NetworkSettings struct {
+	AsyncDNS              bool // AsyncDNS looks up hosts without blocking the UI.
+	MaxConnections        int
+	MaxConnectionsPerHost int
+	Retries               int // Retries is how often a failed connection is retried.
+	// ReceiveTimeout is how long a connection may go without data before
+	// it's retried. It's rounded to seconds.
+	ReceiveTimeout time.Duration
+	// UnrestartableReceiveTimeout is the ReceiveTimeout of downloads which
+	// can't be resumed.
+	UnrestartableReceiveTimeout time.Duration
+	// BindAddress is the local IPv4 address connections are made from,
+	// which selects the interface of a multi-homed host. A nil address is
+	// left unchanged.
+	BindAddress net.IP
+	// BindAddressIPv6 is the BindAddress of IPv6 connections.
+	BindAddressIPv6 net.IP
+}
+
+// bindFields returns the bind address fields of ns, or an error if an
+// address is not of the family of its field.
+func (ns NetworkSettings) bindFields() (v4, v6 string, err error) {
+	if ns.BindAddress != nil {
+		if ns.BindAddress.To4() == nil {
+			return "", "", fmt.Errorf("invalid IPv4 bind address: %v", ns.BindAddress)
+		}
+		v4 = ns.BindAddress.String()
+	}
+	if ns.BindAddressIPv6 != nil {
+		if ns.BindAddressIPv6.To16() == nil || ns.BindAddressIPv6.To4() != nil {
+			return "", "", fmt.Errorf("invalid IPv6 bind address: %v", ns.BindAddressIPv6)
+		}
+		v6 = ns.BindAddressIPv6.String()
+	}
+	return v4, v6, nil
+}
+
+// SetNetworkSettings sets the network options of the running links2.
+// Use SaveSettings to persist them.
+func (b *Browser) SetNetworkSettings(ns NetworkSettings) error {
+	return b.SetNetworkSettingsContext(context.Background(), ns)
+}
+
+// SetNetworkSettingsContext is like SetNetworkSettings but bounds waits by ctx.
+func (b *Browser) SetNetworkSettingsContext(ctx context.Context, ns NetworkSettings) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	v4, v6, err := ns.bindFields()
+	if err != nil {
+		return err
+	}
+	if err := b.openDialog(ctx, "Setup", "Network options"); err != nil {
+		return err
+	}
+	return b.setControls(map[string]bool{labelAsyncDNS: ns.AsyncDNS},
+		itoaField(ns.MaxConnections),
+		itoaField(ns.MaxConnectionsPerHost),
+		itoaField(ns.Retries),
+		itoaField(seconds(ns.ReceiveTimeout)),
+		itoaField(seconds(ns.UnrestartableReceiveTimeout)),
+		v4, v6,
+	)
+}
+
+// SetBindAddress binds subsequent connections of the running links2 to the
+// local address ip through the Setup→Network options dialog, leaving the
+// other network options unchanged. The address family selects the IPv4 or
+// IPv6 field.
+func (b *Browser) SetBindAddress(ip net.IP) error {
+	return b.SetBindAddressContext(context.Background(), ip)
+}
+
+// SetBindAddressContext is like SetBindAddress but bounds waits by ctx.
+func (b *Browser) SetBindAddressContext(ctx context.Context, ip net.IP) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	v4, v6, err := bindSettings(ip).bindFields()
+	if err != nil {
+		return err
+	}
+	if err := b.openDialog(ctx, "Setup", "Network options"); err != nil {
+		return err
+	}
+	return b.setControls(nil, "", "", "", "", "", v4, v6)
+}
+
+// bindSettings returns the NetworkSettings binding to ip.
+func bindSettings(ip net.IP) NetworkSettings {
+	if ip.To4() != nil {
+		return NetworkSettings{BindAddress: ip}
+	}
+	return NetworkSettings{BindAddressIPv6: ip}
+}
+
+// WithBindAddress runs links2 with -bind-address ip, or -bind-address-ipv6
+// for an IPv6 address, so that it connects from the interface of ip.
+func WithBindAddress(ip net.IP) Option {
+	return func(o *options) error {
+		if ip == nil {
+			return fmt.Errorf("invalid bind address: %v", ip)
+		}
+		v4, v6, err := bindSettings(ip).bindFields()
+		if err != nil {
+			return err
+		}
+		if v4 != "" {
+			o.args = append(o.args, "-bind-address", v4)
+		} else {
+			o.args = append(o.args, "-bind-address-ipv6", v6)
+		}
+		return nil
+	}
+}
+
+// Configure sets the options of ns in c, e.g. for WithConfig. Bind addresses
+// of the wrong family are skipped.
+func (ns NetworkSettings) Configure(c *config.Config) {
+	async := "0"
+	if ns.AsyncDNS {
+		async = "1"
+	}
+	c.Set("async_dns", async)
+	for _, o := range []struct {
+		name string
+		n    int
+	}{
+		{"max_connections", ns.MaxConnections},
+		{"max_connections_to_host", ns.MaxConnectionsPerHost},
+		{"retries", ns.Retries},
+		{"receive_timeout", seconds(ns.ReceiveTimeout)},
+		{"unrestartable_receive_timeout", seconds(ns.UnrestartableReceiveTimeout)},
+	} {
+		if o.n > 0 {
+			c.Set(o.name, strconv.Itoa(o.n))
+		}
+	}
+	v4, v6, _ := ns.bindFields()
+	if v4 != "" {
+		c.Set("bind_address", v4)
+	}
+	if v6 != "" {
+		c.Set("bind_address_ipv6", v6)
+	}
+}
+
+// WithAsyncDNS runs links2 -async-dns, which turns the asynchronous host
+// lookup of Setup→Network options on or off.
+func WithAsyncDNS(on bool) Option {
+	return func(o *options) error {
+		v := "0"
+		if on {
+			v = "1"
+		}
+		o.args = append(o.args, "-async-dns", v)
+		return nil
+	}
+}
+
+// WithMaxConnections runs links2 with -max-connections n and
+// -max-connections-to-host perHost. Both must be positive.
+func WithMaxConnections(n, perHost int) Option {
+	return func(o *options) error {
+		if n < 1 || perHost < 1 || perHost > n {
+			return fmt.Errorf("invalid max connections: %d, %d per host", n, perHost)
+		}
+		o.args = append(o.args, "-max-connections", strconv.Itoa(n), "-max-connections-to-host", strconv.Itoa(perHost))
+		return nil
+	}
+}
+
+// WithRetries runs links2 -retries n, how often a failed connection is
+// retried before Navigate fails.
+func WithRetries(n int) Option {
+	return func(o *options) error {
+		if n < 0 {
+			return fmt.Errorf("invalid retries: %d", n)
+		}
+		o.args = append(o.args, "-retries", strconv.Itoa(n))
+		return nil
+	}
+}
+
+// WithReceiveTimeout runs links2 with -receive-timeout d and
+// -unrestartable-receive-timeout unrestartable, how long a connection may go
+// without data before it's retried, as described by NetworkSettings. They're
+// rounded to seconds, which must be at least one. Navigate waits for
+// links2, so its timeout should allow for the retries.
+func WithReceiveTimeout(d, unrestartable time.Duration) Option {
+	return func(o *options) error {
+		if seconds(d) < 1 || seconds(unrestartable) < 1 {
+			return fmt.Errorf("invalid receive timeout: %v, %v unrestartable", d, unrestartable)
+		}
+		o.args = append(o.args,
+			"-receive-timeout", strconv.Itoa(seconds(d)),
+			"-unrestartable-receive-timeout", strconv.Itoa(seconds(unrestartable)))
+		return nil
+	}
+}
+
+// seconds returns d rounded to whole seconds.
+func seconds(d time.Duration) int { return int(d.Round(time.Second) / time.Second) }
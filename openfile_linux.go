@@ -0,0 +1,29 @@
+//go:build linux
+
+package links2
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// fileOpenBy reports whether the process pid has the file at path open. ok
+// is false if it can't tell.
+func fileOpenBy(pid int, path string) (open, ok bool) {
+	want, err := os.Stat(path)
+	if err != nil {
+		return false, false
+	}
+	dir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	fds, err := os.ReadDir(dir)
+	if err != nil {
+		return false, false
+	}
+	for _, fd := range fds {
+		if fi, err := os.Stat(filepath.Join(dir, fd.Name())); err == nil && os.SameFile(fi, want) {
+			return true, true
+		}
+	}
+	return false, true
+}
@@ -0,0 +1,6 @@
+//go:build !linux
+
+package links2
+
+// fileOpenBy can't tell whether a process has a file open without /proc.
+func fileOpenBy(pid int, path string) (open, ok bool) { return false, false }
@@ -0,0 +1,93 @@
+package links2
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/Netflix/go-expect"
+	"github.com/ajzaff/links2/config"
+)
+
+// Option configures a Browser when it's opened.
+type Option func(*options) error
+
+// options are the settings applied by Options.
+type options struct {
+	args     []string // args are extra links2 command-line arguments.
+	timeouts Timeouts
+	restart  bool // restart after an unexpected exit.
+	logger   *slog.Logger
+	cols     int // cols and rows are the PTY size, if set.
+	rows     int
+	patterns Patterns
+	// patternsSet suppresses detection of patterns from the environment.
+	patternsSet bool
+	config      config.Config // config is written to a temporary home, if set.
+	// configure are applied to config after the options.
+	configure     []func(*config.Config)
+	driver        Driver
+	graphics      string // graphics is the graphics mode driver, if any.
+	credentials   Credentials
+	certPolicy    CertPolicy
+	teeOut        io.Writer
+	teeSent       func(keys string)
+	console       func(stdout io.Writer) (Console, error)
+	metrics       Metrics
+	tracer        Tracer
+	limiter       func(host string) Limiter
+	tmuxSession   string // tmuxSession runs links2 in a new tmux session, if set.
+	downloadDir   string // downloadDir is the working directory of links2, if set.
+	contentPolicy ContentPolicy
+	mailto        func(addr *url.URL)
+	retries       int // retries is the number of Navigate attempts, if set.
+	backoff       BackoffFunc
+	headers       http.Header // headers are added to requests by a proxy, if set.
+	progress      func(Progress)
+	keymap        Keymap // keymap overrides the keys of driver.
+	initialize    bool   // initialize calls Initialize after Open.
+	anonymous     bool   // anonymous runs links2 -anonymous.
+	asciicast     io.Writer
+	capture       bool // capture keeps the output tail for LastOutput.
+	captureOut    io.Writer
+	saveProgress  func(SaveProgress)
+	consoleOpts   []expect.ConsoleOpt
+	gotoHistory   []string       // gotoHistory seeds the Go to URL history of a fresh home.
+	cookies       []*http.Cookie // cookies are those of SetCookies.
+	resultCache   *ResultCache
+	profile       *Profile                              // profile is the home of WithProfile, if set.
+	pty           func() (pty, tty *os.File, err error) // pty opens the PTY of WithPTY, if set.
+}
+
+func newOptions(opts []Option) (*options, error) {
+	o := &options{timeouts: DefaultTimeouts, logger: discardLogger, driver: Links2}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	if len(o.configure) > 0 {
+		cfg := append(config.Config{}, o.config...)
+		for _, f := range o.configure {
+			f(&cfg)
+		}
+		o.config = cfg
+	}
+	if !o.patternsSet && o.driver == Links2 {
+		o.patterns = detectPatterns()
+	}
+	if dp := o.driver.Patterns(); len(dp) > 0 {
+		// Explicit patterns take precedence over the driver's.
+		merged := make(Patterns, len(dp)+len(o.patterns))
+		for k, v := range dp {
+			merged[k] = v
+		}
+		for k, v := range o.patterns {
+			merged[k] = v
+		}
+		o.patterns = merged
+	}
+	return o, nil
+}
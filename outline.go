@@ -0,0 +1,105 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// Heading is a heading of a document outline.
+type Heading struct {
+	Level int    // Level is 1 to 6, from h1 to h6.
+	Text  string // Text is the text of the heading with spaces collapsed.
+	ID    string // ID is the id attribute of the heading, if any, for GoToAnchor.
+	Line  int    // Line is the 0-based line of the formatted document, or -1 if not found.
+}
+
+// Outline returns the headings of the current document in document order.
+// The headings are parsed from the source and located in the formatted
+// document, as saved by SaveFormattedDocument, by their text.
+func (b *Browser) Outline() ([]Heading, error) {
+	return b.OutlineContext(context.Background())
+}
+
+// OutlineContext is like Outline but bounds waits by ctx.
+func (b *Browser) OutlineContext(ctx context.Context) ([]Heading, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	var src bytes.Buffer
+	if err := b.writeSaved(&src, func(path string) error { return b.saveSource(ctx, path) }); err != nil {
+		return nil, err
+	}
+	doc, err := b.formattedDocument(ctx)
+	if err != nil {
+		return nil, err
+	}
+	headings := outline(src.String())
+	locateHeadings(headings, documentLines(doc))
+	return headings, nil
+}
+
+// headingLevel returns the level of a heading tag, or 0 for other tags.
+func headingLevel(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && '1' <= tag[1] && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+	return 0
+}
+
+// outline returns the headings of an HTML document with unknown lines.
+func outline(src string) []Heading {
+	var (
+		headings []Heading
+		cur      *Heading
+		text     strings.Builder
+		skip     int
+	)
+	for _, tok := range tokenize(src) {
+		switch tok.kind {
+		case startToken:
+			if skipTags[tok.tag] {
+				skip++
+			} else if n := headingLevel(tok.tag); n > 0 && skip == 0 && cur == nil {
+				cur = &Heading{Level: n, ID: tok.attrs["id"], Line: -1}
+				text.Reset()
+			} else if cur != nil && cur.ID == "" && tok.tag == "a" {
+				// An anchor inside the heading names it.
+				cur.ID = tok.attrs["name"]
+			}
+		case endToken:
+			if skipTags[tok.tag] && skip > 0 {
+				skip--
+			} else if cur != nil && headingLevel(tok.tag) == cur.Level {
+				cur.Text = strings.Join(strings.Fields(text.String()), " ")
+				if cur.Text != "" {
+					headings = append(headings, *cur)
+				}
+				cur = nil
+			}
+		case textToken:
+			if cur != nil && skip == 0 {
+				text.WriteString(tok.text)
+				text.WriteByte(' ')
+			}
+		}
+	}
+	return headings
+}
+
+// locateHeadings sets the lines of headings in the document lines, looking
+// for each heading after the previous one. A heading wrapped over several
+// lines is located at its first line.
+func locateHeadings(headings []Heading, lines []string) {
+	from := 0
+	for i := range headings {
+		h := &headings[i]
+		for n := from; n < len(lines); n++ {
+			line := strings.Join(strings.Fields(lines[n]), " ")
+			if line != "" && (strings.Contains(line, h.Text) || strings.HasPrefix(h.Text, line)) {
+				h.Line = n
+				from = n + 1
+				break
+			}
+		}
+	}
+}
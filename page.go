@@ -0,0 +1,184 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const saveAsDialog = "Save to file \033[0;7m"
+
+// PageText returns the formatted text of the whole current document, as
+// rendered at the current terminal width, rather than just the screen.
+func (b *Browser) PageText() (string, error) {
+	return b.PageTextContext(context.Background())
+}
+
+// PageTextContext is like PageText but bounds waits by ctx.
+func (b *Browser) PageTextContext(ctx context.Context) (string, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	return b.formattedDocument(ctx)
+}
+
+// WriteFormattedDocument writes the formatted text of the current document
+// to w. The document is saved to a temporary file which is then removed.
+func (b *Browser) WriteFormattedDocument(w io.Writer) error {
+	return b.WriteFormattedDocumentContext(context.Background(), w)
+}
+
+// WriteFormattedDocumentContext is like WriteFormattedDocument but bounds waits by ctx.
+func (b *Browser) WriteFormattedDocumentContext(ctx context.Context, w io.Writer) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	return b.writeSaved(w, func(path string) error {
+		return b.SaveFormattedDocumentContext(ctx, path, false)
+	})
+}
+
+// WriteSource writes the source of the current document to w, as saved by
+// File→Save as. The document is saved to a temporary file which is then
+// removed.
+func (b *Browser) WriteSource(w io.Writer) error {
+	return b.WriteSourceContext(context.Background(), w)
+}
+
+// WriteSourceContext is like WriteSource but bounds waits by ctx.
+func (b *Browser) WriteSourceContext(ctx context.Context, w io.Writer) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	return b.writeSaved(w, func(path string) error { return b.saveSource(ctx, path) })
+}
+
+// saveSource saves the source of the current document to the new file path.
+func (b *Browser) saveSource(ctx context.Context, path string) error {
+	if err := b.checkAnonymous("save source"); err != nil {
+		return err
+	}
+	if err := checkInput(path); err != nil {
+		return err
+	}
+	if err := b.OpenMenuContext(ctx, "File", "Save as"); err != nil {
+		return err
+	}
+	if _, err := b.expectString(saveAsDialog); err != nil {
+		b.closeMenu()
+		return err
+	}
+	// Replace the suggested file name.
+	b.c.Send("\025" + path + "\n") // ^U
+	b.s = stateIdle
+	b.menuName = ""
+	return b.waitFile(path, nil)
+}
+
+// formattedDocument returns the formatted text of the current document.
+func (b *Browser) formattedDocument(ctx context.Context) (string, error) {
+	var buf bytes.Buffer
+	err := b.writeSaved(&buf, func(path string) error {
+		return b.SaveFormattedDocumentContext(ctx, path, false)
+	})
+	return buf.String(), err
+}
+
+// writeSaved calls save with the path of a new file in a temporary
+// directory and copies the file to w as it's saved. If save fails, part of
+// the file may have been copied.
+func (b *Browser) writeSaved(w io.Writer, save func(path string) error) error {
+	dir, err := os.MkdirTemp("", "links2-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	s := &saveStream{path: filepath.Join(dir, "document"), w: w}
+	defer s.close()
+	prev := b.stream
+	b.stream = s
+	defer func() { b.stream = prev }()
+	if err := save(s.path); err != nil {
+		return err
+	}
+	// Copy what's left if save didn't wait for the file.
+	return s.copy()
+}
+
+// saveSettle is how long the size of a file being saved must stay the same
+// for waitFile to take it as written, when it can't tell whether links2
+// still has the file open.
+const saveSettle = 500 * time.Millisecond
+
+// waitFile waits until the file at path exists, differs from old (if any)
+// and links2 closed it, bounded by the Menu timeout without growth and the
+// operation context. Where it can't tell whether links2 has the file open,
+// e.g. without /proc or a links2 process, the file is taken as written once
+// it's not empty and its size stayed the same for saveSettle. A file
+// rewritten with the same size within the modification time granularity is
+// taken as written on timeout. The file of writeSaved is copied as it grows,
+// and growth is reported to WithSaveProgress.
+func (b *Browser) waitFile(path string, old os.FileInfo) error {
+	var s *saveStream
+	if b.stream != nil && b.stream.path == path {
+		s = b.stream
+	}
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	pid := 0
+	if b.cmd != nil && b.cmd.Process != nil {
+		pid = b.cmd.Process.Pid
+	}
+	deadline := time.Now().Add(b.timeouts.Menu)
+	size := int64(-1)
+	var settled time.Time // settled is when size last changed.
+	for {
+		fi, err := os.Stat(path)
+		unchanged := err == nil && old != nil && fi.Size() == old.Size() && fi.ModTime().Equal(old.ModTime())
+		if err == nil && !unchanged {
+			if fi.Size() != size {
+				size, settled = fi.Size(), time.Now()
+				deadline = settled.Add(b.timeouts.Menu)
+				if s != nil {
+					if err := s.copy(); err != nil {
+						return err
+					}
+				}
+				b.reportSave(path, size, false)
+			}
+			written := size > 0 && time.Since(settled) >= saveSettle
+			if pid != 0 {
+				if open, ok := fileOpenBy(pid, path); ok {
+					written = !open
+				}
+			}
+			if written {
+				if s != nil {
+					if err := s.copy(); err != nil {
+						return err
+					}
+				}
+				b.reportSave(path, size, true)
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			switch {
+			case err != nil:
+				return fmt.Errorf("wait for %s: %w", path, err)
+			case unchanged:
+				b.reportSave(path, fi.Size(), true)
+				return nil
+			}
+			return fmt.Errorf("wait for %s: %w: %w", path, ErrTimeout, os.ErrDeadlineExceeded)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package links2
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// slowWrite writes chunks to a new file at path, pausing before each chunk
+// and before closing the file.
+func slowWrite(t *testing.T, path string, chunks [][]byte, pause time.Duration) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer f.Close()
+	for _, c := range chunks {
+		time.Sleep(pause)
+		if _, err := f.Write(c); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	time.Sleep(pause)
+}
+
+func testWaitFileSlowWriter(t *testing.T, b *Browser, pause time.Duration) {
+	chunks := [][]byte{[]byte("first\n"), bytes.Repeat([]byte("x"), 64<<10), []byte("last\n")}
+	var got bytes.Buffer
+	err := b.writeSaved(&got, func(path string) error {
+		go slowWrite(t, path, chunks, pause)
+		return b.waitFile(path, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := bytes.Join(chunks, nil); !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("copied %d bytes, want %d", got.Len(), len(want))
+	}
+}
+
+// TestWaitFileSlowWriter checks that pauses shorter than saveSettle, with an
+// empty file first, don't end the wait without a links2 process.
+func TestWaitFileSlowWriter(t *testing.T) {
+	b := &Browser{}
+	b.timeouts = DefaultTimeouts
+	testWaitFileSlowWriter(t, b, saveSettle/3)
+}
+
+// TestWaitFileOpen checks that the wait lasts while the process standing in
+// for links2 has the file open, however long it pauses.
+func TestWaitFileOpen(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("needs /proc")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	b := &Browser{}
+	b.timeouts = DefaultTimeouts
+	var got bytes.Buffer
+	err := b.writeSaved(&got, func(path string) error {
+		b.cmd = exec.Command("sh", "-c", `exec 3>"$1"; sleep 1; printf first >&3; sleep 1; printf ' last' >&3; sleep 1`, "sh", path)
+		if err := b.cmd.Start(); err != nil {
+			return err
+		}
+		defer b.cmd.Wait()
+		return b.waitFile(path, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "first last" {
+		t.Errorf("copied %q, want %q", got.String(), "first last")
+	}
+}
@@ -0,0 +1,289 @@
+package links2
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Netflix/go-expect"
+)
+
+// Patterns translates the English UI text matched by the package into the
+// text of a localized links2 build. Keys are English phrases as drawn on the
+// screen, e.g. "Go to URL", and are replaced wherever they occur in a
+// pattern, menu label or dialog label. A key may also be a whole pattern when
+// its framing depends on the length of the text.
+type Patterns map[string]string
+
+// Screen patterns matched in the output of links2, in English as drawn by
+// the base table. They are the keys of Patterns and PatternTable.Regexps.
+const (
+	dropdownMenu = "File  \033[0;7m  View    Link    Downloads    Setup    Help"
+	exitPrompt   = "Do you really want to exit Links?"
+	// exitDownloads is the exit confirmation shown while downloading.
+	exitDownloads = "Do you really want to exit Links and terminate all downloads?"
+	goToMenu      = "Go to URL \033[0;7m---------------------------+"
+)
+
+const (
+	lookupHost        = "Looking up host\033[0m"
+	makeConnection    = "Making connection\033[0m"
+	requestSent       = "Request sent\033[0m"
+	sslNegotiate      = "SSL negotiation\033[0m"
+	formatDocument    = "Formatting document\033[0m"
+	hostNotFound      = "Host not found"
+	errorText         = "Error \033[0;7m"
+	noSuchFile        = "No such file or directory\033[13;"
+	sslError          = "SSL error"
+	fileAlreadyExists = "File already exists \033[10;"
+	downloadDialog    = "Download \033[0;7m"
+	downloadReceived  = "Received "
+	noDownloads       = "No downloads"
+)
+
+var (
+	patternsMu sync.RWMutex
+	// patternTables are the registered Patterns keyed by language.
+	patternTables = map[string]Patterns{"en": nil}
+	// versionTables are the registered PatternTables in release order. The
+	// base table matches the patterns as drawn on a screen of the default
+	// size.
+	versionTables = []PatternTable{{}, {
+		Major: 2,
+		Regexps: map[string]string{
+			// Links2 centers message boxes, so their row depends on the
+			// screen size.
+			noSuchFile:        `No such file or directory\x1b\[\d+;`,
+			fileAlreadyExists: `File already exists \x1b\[\d+;`,
+		},
+	}}
+)
+
+// PatternTable holds the screen patterns of the links2 releases from
+// Major.Minor on, until the next registered release. Release tables are
+// selected by the version detected at Open.
+type PatternTable struct {
+	Major, Minor int
+	// Patterns replaces the English patterns of the release, like the
+	// Patterns of a language. The Patterns of WithLanguage or WithPatterns
+	// take precedence.
+	Patterns Patterns
+	// Regexps match the patterns with the given English keys by a regular
+	// expression instead of their text, e.g. to allow for the dialog
+	// coordinates drawn with the text. A key is a whole pattern such as
+	// "Go to URL \x1b[0;7m". Regexps are not translated.
+	Regexps map[string]string
+}
+
+// RegisterPatternTable registers the patterns of a links2 release, replacing
+// any previous table of the release. It panics if a regexp doesn't compile.
+func RegisterPatternTable(t PatternTable) {
+	for _, expr := range t.Regexps {
+		regexp.MustCompile(expr)
+	}
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	for i, old := range versionTables {
+		if old.Major == t.Major && old.Minor == t.Minor {
+			versionTables[i] = t
+			return
+		}
+	}
+	versionTables = append(versionTables, t)
+	sort.Slice(versionTables, func(i, j int) bool {
+		a, b := versionTables[i], versionTables[j]
+		if a.Major != b.Major {
+			return a.Major < b.Major
+		}
+		return a.Minor < b.Minor
+	})
+}
+
+// lookupPatternTable returns the table of the latest release at or before
+// v. Unknown versions are assumed to be recent, like Version.AtLeast.
+func lookupPatternTable(v Version) PatternTable {
+	patternsMu.RLock()
+	defer patternsMu.RUnlock()
+	t := versionTables[0]
+	for _, next := range versionTables[1:] {
+		if !v.AtLeast(next.Major, next.Minor) {
+			break
+		}
+		t = next
+	}
+	return t
+}
+
+// RegisterPatterns registers the Patterns of links2 builds using the given
+// language, e.g. "de" or "pt_BR", replacing any previous table.
+func RegisterPatterns(lang string, p Patterns) {
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	patternTables[lang] = p
+}
+
+func lookupPatterns(lang string) (Patterns, bool) {
+	patternsMu.RLock()
+	defer patternsMu.RUnlock()
+	p, ok := patternTables[lang]
+	return p, ok
+}
+
+// WithLanguage selects the registered Patterns of lang. By default the
+// language is detected from the locale environment links2 uses, falling back
+// to English.
+func WithLanguage(lang string) Option {
+	return func(o *options) error {
+		p, ok := lookupPatterns(lang)
+		if !ok {
+			return fmt.Errorf("no patterns for language %q", lang)
+		}
+		o.patterns = p
+		o.patternsSet = true
+		return nil
+	}
+}
+
+// WithPatterns uses p to match the UI of a localized links2 build.
+func WithPatterns(p Patterns) Option {
+	return func(o *options) error {
+		o.patterns = p
+		o.patternsSet = true
+		return nil
+	}
+}
+
+// detectPatterns returns the registered Patterns of the locale set by the
+// environment, e.g. "de" for LANG=de_DE.UTF-8, or nil if there are none.
+func detectPatterns() Patterns {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		locale, _, _ = strings.Cut(locale, ".")
+		if p, ok := lookupPatterns(locale); ok {
+			return p
+		}
+		lang, _, _ := strings.Cut(locale, "_")
+		p, _ := lookupPatterns(lang)
+		return p
+	}
+	return nil
+}
+
+// replacer returns a replacer applying p, preferring longer keys.
+func (p Patterns) replacer() *strings.Replacer {
+	if len(p) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	oldnew := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		oldnew = append(oldnew, k, p[k])
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// tr translates the English UI text s for the running links2.
+func (b *Browser) tr(s string) string { return translate(b.patterns, s) }
+
+// translate translates s with patterns, which may be nil.
+func translate(patterns *strings.Replacer, s string) string {
+	if patterns == nil {
+		return s
+	}
+	return patterns.Replace(s)
+}
+
+// patternRegexp is a compiled regexp of PatternTable.Regexps.
+type patternRegexp struct {
+	re     *regexp.Regexp
+	suffix *regexp.Regexp // suffix matches re at the end of the output.
+}
+
+// compileRegexps compiles the regexps of t.
+func (t PatternTable) compileRegexps() map[string]patternRegexp {
+	if len(t.Regexps) == 0 {
+		return nil
+	}
+	m := make(map[string]patternRegexp, len(t.Regexps))
+	for k, expr := range t.Regexps {
+		m[k] = patternRegexp{
+			re:     regexp.MustCompile(expr),
+			suffix: regexp.MustCompile(`(?:` + expr + `)\z`),
+		}
+	}
+	return m
+}
+
+// merge returns the Patterns of t overridden by p.
+func (t PatternTable) merge(p Patterns) Patterns {
+	if len(t.Patterns) == 0 {
+		return p
+	}
+	merged := make(Patterns, len(t.Patterns)+len(p))
+	for k, v := range t.Patterns {
+		merged[k] = v
+	}
+	for k, v := range p {
+		merged[k] = v
+	}
+	return merged
+}
+
+// match returns an ExpectOpt matching any of the English patterns ss,
+// translated or by their regexp.
+func (b *Browser) match(ss ...string) expect.ExpectOpt {
+	var res []*regexp.Regexp
+	for _, s := range ss {
+		if _, ok := b.regexps[s]; ok {
+			res = make([]*regexp.Regexp, len(ss))
+			break
+		}
+	}
+	if res == nil {
+		translated := make([]string, len(ss))
+		for i, s := range ss {
+			translated[i] = b.tr(s)
+		}
+		return expect.String(translated...)
+	}
+	for i, s := range ss {
+		if r, ok := b.regexps[s]; ok {
+			res[i] = r.re
+		} else {
+			res[i] = regexp.MustCompile(regexp.QuoteMeta(b.tr(s)))
+		}
+	}
+	return expect.Regexp(res...)
+}
+
+// matched reports whether the output buf read by an expect of match ends
+// with the English pattern s.
+func (b *Browser) matched(buf, s string) bool {
+	if r, ok := b.regexps[s]; ok {
+		return r.suffix.MatchString(buf)
+	}
+	return strings.HasSuffix(buf, b.tr(s))
+}
+
+// contains reports whether the output buf contains the English pattern s.
+func (b *Browser) contains(buf, s string) bool {
+	if r, ok := b.regexps[s]; ok {
+		return r.re.MatchString(buf)
+	}
+	return strings.Contains(buf, b.tr(s))
+}
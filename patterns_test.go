@@ -0,0 +1,43 @@
+package links2
+
+import "testing"
+
+func TestLookupPatternTable(t *testing.T) {
+	tests := []struct {
+		v     Version
+		major int
+	}{
+		{Version{}, 2}, // Unknown versions are assumed to be recent.
+		{Version{Major: 1, Minor: 0, Text: "Links 1.00"}, 0},
+		{Version{Major: 2, Minor: 0, Text: "Links 2.0"}, 2},
+		{Version{Major: 2, Minor: 29, Text: "Links 2.29"}, 2},
+	}
+	for _, tc := range tests {
+		if got := lookupPatternTable(tc.v); got.Major != tc.major {
+			t.Errorf("lookupPatternTable(%v) = table %d.%d, want %d.0", tc.v, got.Major, got.Minor, tc.major)
+		}
+	}
+}
+
+func TestRegisterPatternTable(t *testing.T) {
+	saved := append([]PatternTable(nil), versionTables...)
+	t.Cleanup(func() { versionTables = saved })
+	RegisterPatternTable(PatternTable{Major: 2, Minor: 30, Patterns: Patterns{exitPrompt: "Exit?"}})
+	RegisterPatternTable(PatternTable{Major: 2, Minor: 10})
+	for i := 1; i < len(versionTables); i++ {
+		a, b := versionTables[i-1], versionTables[i]
+		if a.Major > b.Major || a.Major == b.Major && a.Minor >= b.Minor {
+			t.Fatalf("tables out of release order: %d.%d before %d.%d", a.Major, a.Minor, b.Major, b.Minor)
+		}
+	}
+	got := lookupPatternTable(Version{Major: 2, Minor: 31, Text: "Links 2.31"})
+	if got.Minor != 30 || got.merge(nil)[exitPrompt] != "Exit?" {
+		t.Errorf("lookupPatternTable(2.31) = %+v, want the 2.30 table", got)
+	}
+	if got := lookupPatternTable(Version{Major: 2, Minor: 20, Text: "Links 2.20"}); got.Minor != 10 {
+		t.Errorf("lookupPatternTable(2.20) = table %d.%d, want 2.10", got.Major, got.Minor)
+	}
+	if regexps := lookupPatternTable(Version{Major: 2, Minor: 5, Text: "Links 2.5"}).compileRegexps(); regexps[noSuchFile].re == nil {
+		t.Errorf("2.0 table has no regexp for %q", noSuchFile)
+	}
+}
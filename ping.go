@@ -0,0 +1,49 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+)
+
+// Ping verifies that links2 is running and its UI responds by opening and
+// closing the dropdown menu, bounded by ctx and the Menu timeout. It's meant
+// for health checks, e.g. by a Pool or a liveness probe, and leaves links2
+// idle. Ping waits for an operation in progress to finish, so a busy Browser
+// fails a Ping whose ctx is done first.
+func (b *Browser) Ping(ctx context.Context) error {
+	locked := make(chan struct{})
+	var err error
+	go func() {
+		defer close(locked)
+		_, done := b.begin(ctx)
+		defer done()
+		if ctx.Err() != nil {
+			// The Ping returned while waiting for the lock.
+			return
+		}
+		err = b.ping()
+	}()
+	select {
+	case <-locked:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("ping: %w", ctx.Err())
+	}
+}
+
+func (b *Browser) ping() error {
+	if b.s == stateUndefined {
+		return ErrNotStarted
+	}
+	if err := b.closeMenu(); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	if err := b.openDropDownMenu(); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	b.menuName = menuDropdown
+	if err := b.closeMenu(); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	return nil
+}
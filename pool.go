@@ -0,0 +1,94 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Pool manages a fixed number of Browsers for parallel use.
+//
+// Browsers are opened lazily by Acquire and handed back with Release. A
+// Browser released with an error, e.g. because links2 crashed, is closed and
+// replaced by a freshly opened Browser on a later Acquire.
+type Pool struct {
+	opts []Option
+	sem  chan struct{} // sem holds a token per Browser in use.
+
+	mu     sync.Mutex
+	idle   []*Browser
+	closed bool
+}
+
+// NewPool returns a Pool of up to n Browsers opened with opts.
+func NewPool(n int, opts ...Option) *Pool {
+	if n <= 0 {
+		panic("links2: NewPool: n must be positive")
+	}
+	return &Pool{opts: opts, sem: make(chan struct{}, n)}
+}
+
+// Acquire returns an idle Browser, opening one if needed, blocking until one
+// is available or ctx is done. The Browser must be returned with Release.
+func (p *Pool) Acquire(ctx context.Context) (*Browser, error) {
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.sem
+		return nil, fmt.Errorf("pool closed")
+	}
+	var b *Browser
+	if n := len(p.idle); n > 0 {
+		b = p.idle[n-1]
+		p.idle = p.idle[:n-1]
+	}
+	p.mu.Unlock()
+	if b != nil {
+		select {
+		case <-b.Exited():
+			// Crashed while idle; replace it.
+			b.Close()
+		default:
+			return b, nil
+		}
+	}
+	b = new(Browser)
+	if err := b.OpenContext(ctx, p.opts...); err != nil {
+		<-p.sem
+		return nil, err
+	}
+	return b, nil
+}
+
+// Release returns b to the pool. If err is non-nil b is assumed broken, so
+// it's closed rather than recycled.
+func (p *Pool) Release(b *Browser, err error) {
+	defer func() { <-p.sem }()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil || p.closed {
+		b.Close()
+		return
+	}
+	p.idle = append(p.idle, b)
+}
+
+// Close quits the idle Browsers. Browsers in use are closed when released.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle, p.closed = nil, true
+	p.mu.Unlock()
+	var err error
+	for _, b := range idle {
+		if err1 := b.Quit(); err == nil {
+			err = err1
+		}
+	}
+	return err
+}
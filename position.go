@@ -0,0 +1,146 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Position returns the scroll position of the current document: the 0-based
+// document line at the top of the screen and how far through the document
+// the bottom of the screen is, from 0 to 100.
+//
+// Links2 doesn't display the position, so it's found by saving the formatted
+// document and locating the text on screen in it. Horizontal scrolling is
+// not accounted for.
+func (b *Browser) Position() (line, percent int, err error) {
+	return b.PositionContext(context.Background())
+}
+
+// PositionContext is like Position but bounds waits by ctx.
+func (b *Browser) PositionContext(ctx context.Context) (line, percent int, err error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.closeMenu(); err != nil {
+		return 0, 0, err
+	}
+	if _, err := b.drain(); err != nil {
+		return 0, 0, err
+	}
+	rows := b.documentRows()
+	doc, err := b.formattedDocument(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	return locate(rows, documentLines(doc))
+}
+
+// documentRows returns the rows of the screen showing the document.
+// The first and last rows hold the title and status bars.
+func (b *Browser) documentRows() []string {
+	lines := b.scr.lines()
+	return lines[1 : len(lines)-1]
+}
+
+// documentLines splits formatted document text into lines with trailing
+// spaces trimmed.
+func documentLines(doc string) []string {
+	lines := strings.Split(strings.TrimRight(doc, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+// locate finds the screen rows in the document lines and returns the
+// position of the first one.
+func locate(rows, lines []string) (line, percent int, err error) {
+	// Rows past the end of a short document are blank.
+	n := len(rows)
+	for n > 0 && rows[n-1] == "" {
+		n--
+	}
+	if n == 0 || len(lines) == 0 {
+		return 0, 100, nil
+	}
+	for i := 0; i+n <= len(lines); i++ {
+		if equalLines(lines[i:i+n], rows[:n]) {
+			bottom := min(i+len(rows), len(lines))
+			return i, bottom * 100 / len(lines), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("position: screen text not found in document")
+}
+
+// maxScrollAttempts bounds how often GoToLine corrects the scroll position.
+const maxScrollAttempts = 3
+
+// GoToLine scrolls the current document so line n (0-based) is at the top of
+// the screen, or as close as the end of the document allows.
+func (b *Browser) GoToLine(n int) error {
+	return b.GoToLineContext(context.Background(), n)
+}
+
+// GoToLineContext is like GoToLine but bounds waits by ctx.
+func (b *Browser) GoToLineContext(ctx context.Context, n int) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	return b.scrollTo(ctx, func(lines, rows int) int { return n })
+}
+
+// GoToPercent scrolls the current document so the bottom of the screen is p
+// percent through it, the inverse of Position.
+func (b *Browser) GoToPercent(p int) error {
+	return b.GoToPercentContext(context.Background(), p)
+}
+
+// GoToPercentContext is like GoToPercent but bounds waits by ctx.
+func (b *Browser) GoToPercentContext(ctx context.Context, p int) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if p < 0 || p > 100 {
+		return fmt.Errorf("invalid percentage: %d", p)
+	}
+	return b.scrollTo(ctx, func(lines, rows int) int { return p*lines/100 - rows })
+}
+
+// scrollTo scrolls line by line to the top line returned by target, given
+// the number of document lines and screen rows, checking the position after
+// each batch of scrolling.
+func (b *Browser) scrollTo(ctx context.Context, target func(lines, rows int) int) error {
+	if err := b.closeMenu(); err != nil {
+		return err
+	}
+	doc, err := b.formattedDocument(ctx)
+	if err != nil {
+		return err
+	}
+	lines := documentLines(doc)
+	if _, err := b.drain(); err != nil {
+		return err
+	}
+	rows := b.documentRows()
+	n := max(0, min(target(len(lines), len(rows)), len(lines)-1))
+	last := -1
+	for i := 0; i < maxScrollAttempts; i++ {
+		line, _, err := locate(rows, lines)
+		if err != nil {
+			return err
+		}
+		if line == n || line == last {
+			// At the target, or the document can't scroll further.
+			return nil
+		}
+		last = line
+		if line < n {
+			b.c.Send(strings.Repeat("\016", n-line)) // ^N scrolls down a line.
+		} else {
+			b.c.Send(strings.Repeat("\020", line-n)) // ^P scrolls up a line.
+		}
+		if _, err := b.drain(); err != nil {
+			return err
+		}
+		rows = b.documentRows()
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package links2
+
+import "os/exec"
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// signalGroup kills cmd. Process groups and SIGTERM are not supported.
+func signalGroup(cmd *exec.Cmd, kill bool) error { return cmd.Process.Kill() }
@@ -0,0 +1,31 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package links2
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup makes cmd the leader of a new session, and so of a new
+// process group, with its terminal on stdin as the controlling terminal.
+// Children of links2 join the group, so signalGroup reaches them too.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+}
+
+// signalGroup sends SIGTERM, or SIGKILL if kill is set, to the process group
+// of cmd.
+func signalGroup(cmd *exec.Cmd, kill bool) error {
+	sig := syscall.SIGTERM
+	if kill {
+		sig = syscall.SIGKILL
+	}
+	if err := syscall.Kill(-cmd.Process.Pid, sig); err != nil {
+		if err == syscall.ESRCH {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
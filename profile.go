@@ -0,0 +1,249 @@
+package links2
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ajzaff/links2/config"
+	"github.com/ajzaff/links2/cookies"
+	"github.com/ajzaff/links2/history"
+)
+
+// Profile errors.
+var (
+	ErrProfileExists = errors.New("profile already exists")
+	ErrNoProfile     = errors.New("no such profile")
+)
+
+// A Profile is a persistent home directory for links2, with its own
+// ~/.links holding the config, cookies, history, bookmarks and saved
+// authentication, so that Browsers opened with different profiles don't
+// share an identity. A profile should be used by one Browser at a time:
+// links2 saves its state when it exits, replacing what others saved.
+type Profile struct {
+	Name string
+	Dir  string // Dir is used as the home directory of links2.
+}
+
+// linksDir returns the directory links2 keeps its state in.
+func (p Profile) linksDir() string { return filepath.Join(p.Dir, ".links") }
+
+// Cookies returns the cookies in the cookie store of p, as of the last exit
+// of a Browser using p.
+func (p Profile) Cookies() ([]*http.Cookie, error) {
+	cs, err := cookies.Load(filepath.Join(p.linksDir(), "cookies"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return cs, err
+}
+
+// SetCookies replaces the cookie store of p. Links2 loads cookies when it
+// starts, so no Browser should be using p.
+func (p Profile) SetCookies(cs []*http.Cookie) error {
+	return cookies.Save(filepath.Join(p.linksDir(), "cookies"), cs)
+}
+
+// ProfileStore manages named profiles, each in a directory under its root.
+type ProfileStore struct {
+	root string
+}
+
+// NewProfileStore returns a ProfileStore keeping profiles under root, which
+// is created if needed.
+func NewProfileStore(root string) (*ProfileStore, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(abs, 0o700); err != nil {
+		return nil, err
+	}
+	return &ProfileStore{root: abs}, nil
+}
+
+// DefaultProfileStore returns the ProfileStore under the user's config
+// directory, e.g. ~/.config/links2/profiles.
+func DefaultProfileStore() (*ProfileStore, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewProfileStore(filepath.Join(dir, "links2", "profiles"))
+}
+
+// Profile returns the profile name, or ErrNoProfile.
+func (s *ProfileStore) Profile(name string) (Profile, error) {
+	p, err := s.profile(name)
+	if err != nil {
+		return Profile{}, err
+	}
+	if _, err := os.Stat(p.linksDir()); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Profile{}, fmt.Errorf("profile %q: %w", name, ErrNoProfile)
+		}
+		return Profile{}, err
+	}
+	return p, nil
+}
+
+// Profiles returns the names of the profiles, sorted.
+func (s *ProfileStore) Profiles() ([]string, error) {
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if fi, err := os.Stat(filepath.Join(s.root, e.Name(), ".links")); err == nil && fi.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Create creates the empty profile name, or returns ErrProfileExists.
+func (s *ProfileStore) Create(name string) (Profile, error) {
+	p, err := s.profile(name)
+	if err != nil {
+		return Profile{}, err
+	}
+	if err := os.Mkdir(p.Dir, 0o700); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return Profile{}, fmt.Errorf("profile %q: %w", name, ErrProfileExists)
+		}
+		return Profile{}, err
+	}
+	if err := os.Mkdir(p.linksDir(), 0o700); err != nil {
+		os.RemoveAll(p.Dir)
+		return Profile{}, err
+	}
+	return p, nil
+}
+
+// Clone creates the profile dst holding a copy of the state of src, e.g. to
+// start sessions from a logged in identity without sharing it.
+func (s *ProfileStore) Clone(src, dst string) (Profile, error) {
+	from, err := s.Profile(src)
+	if err != nil {
+		return Profile{}, err
+	}
+	to, err := s.Create(dst)
+	if err != nil {
+		return Profile{}, err
+	}
+	if err := copyDir(to.linksDir(), from.linksDir()); err != nil {
+		os.RemoveAll(to.Dir)
+		return Profile{}, fmt.Errorf("clone profile %q: %w", src, err)
+	}
+	return to, nil
+}
+
+// Delete removes the profile name and all its state.
+func (s *ProfileStore) Delete(name string) error {
+	p, err := s.Profile(name)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(p.Dir)
+}
+
+func (s *ProfileStore) profile(name string) (Profile, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return Profile{}, fmt.Errorf("invalid profile name: %q", name)
+	}
+	return Profile{Name: name, Dir: filepath.Join(s.root, name)}, nil
+}
+
+// copyDir copies the regular files and directories under src to dst, which
+// exists.
+func copyDir(dst, src string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.Mkdir(target, 0o700)
+		case d.Type().IsRegular():
+			return copyFile(target, path)
+		}
+		return nil
+	})
+}
+
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// WithProfile runs links2 with the home directory of p, so its state is kept
+// there across runs instead of in the user's ~/.links. The config of
+// WithConfig and other config options, if any, are set in the links.cfg of
+// p, on top of its settings, rather than in a fresh home, and the history of
+// WithGotoHistory and the cookies of SetCookies replace those of p.
+func WithProfile(p Profile) Option {
+	return func(o *options) error {
+		fi, err := os.Stat(p.linksDir())
+		if err != nil {
+			return fmt.Errorf("profile %q: %w", p.Name, err)
+		}
+		if !fi.IsDir() {
+			return fmt.Errorf("profile %q: not a directory: %q", p.Name, p.linksDir())
+		}
+		o.profile = &p
+		return nil
+	}
+}
+
+// configureProfile sets the links.cfg options, Go to URL history and
+// cookies of o, if any, in the profile of o.
+func configureProfile(o *options) error {
+	if o.gotoHistory != nil {
+		if err := history.SaveGoto(filepath.Join(o.profile.linksDir(), history.GotoFile), o.gotoHistory); err != nil {
+			return err
+		}
+	}
+	if o.cookies != nil {
+		if err := o.profile.SetCookies(o.cookies); err != nil {
+			return err
+		}
+	}
+	if o.config == nil {
+		return nil
+	}
+	path := filepath.Join(o.profile.linksDir(), "links.cfg")
+	cfg, err := config.Load(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for _, opt := range o.config {
+		cfg.Set(opt.Name, opt.Args...)
+	}
+	return config.Save(path, cfg)
+}
@@ -0,0 +1,69 @@
+package links2
+
+import (
+	"regexp"
+	"time"
+)
+
+// Progress is the progress of a page load.
+type Progress struct {
+	URL      string
+	Phase    Phase // Phase is the load phase most recently observed.
+	Received int64 // Received is the number of bytes received so far.
+	Total    int64 // Total is the size of the document, or -1 if unknown.
+	Time     time.Time
+}
+
+// WithProgress calls fn with the progress of page loads as links2 reports it
+// in the status bar: on each new load phase and whenever the number of bytes
+// received changes. fn is called by the loading operation and must not use
+// the Browser.
+func WithProgress(fn func(Progress)) Option {
+	return func(o *options) error {
+		o.progress = fn
+		return nil
+	}
+}
+
+// receivingStatus matches the status bar while the document is received,
+// e.g. "Received 12 kB of 40 kB".
+var receivingStatus = regexp.MustCompile(`Received [^\033]*\033\[0m`)
+
+// progressReporter reports the progress of a page load to the WithProgress
+// function, skipping updates which change nothing.
+type progressReporter struct {
+	fn   func(Progress)
+	last Progress
+}
+
+func (b *Browser) progressReporter() *progressReporter {
+	if b.opts == nil || b.opts.progress == nil {
+		return nil
+	}
+	return &progressReporter{fn: b.opts.progress, last: Progress{Phase: -1, Total: -1}}
+}
+
+// update reports the progress shown by the output buf, which was read up to
+// a phase or receiving status, and the last phase of res.
+func (r *progressReporter) update(buf string, res *NavigateResult) {
+	p := r.last
+	if n := len(res.Phases); n > 0 {
+		p.Phase = res.Phases[n-1].Phase
+	}
+	if locs := receivingStatus.FindAllStringIndex(buf, -1); len(locs) > 0 {
+		if loc := locs[len(locs)-1]; loc[1] == len(buf) {
+			if m := receivedRE.FindStringSubmatch(buf[loc[0]:]); m != nil {
+				p.Received = parseByteSize(m[1])
+				if m[2] != "" {
+					p.Total = parseByteSize(m[2])
+				}
+			}
+		}
+	}
+	if p.Phase == r.last.Phase && p.Received == r.last.Received && p.Total == r.last.Total {
+		return
+	}
+	p.URL, p.Time = res.urlString(), time.Now()
+	r.last = p
+	r.fn(p)
+}
@@ -0,0 +1,104 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const proxiesDialog = "Proxies \033[0;7m"
+
+// proxyKind identifies one of the links2 proxy settings.
+type proxyKind int
+
+// Proxy settings in the order of the fields in the Proxies dialog.
+const (
+	proxyHTTP proxyKind = iota
+	proxyFTP
+	proxyHTTPS
+	proxySOCKS
+)
+
+var proxyFlags = [...]string{
+	proxyHTTP:  "-http-proxy",
+	proxyFTP:   "-ftp-proxy",
+	proxyHTTPS: "-https-proxy",
+	proxySOCKS: "-socks-proxy",
+}
+
+// parseProxy parses a proxy URL such as "http://host:port" or
+// "socks://user@host:port" into the links2 proxy setting and its value.
+func parseProxy(rawURL string) (proxyKind, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, "", err
+	}
+	if u.Host == "" || u.Port() == "" {
+		return 0, "", fmt.Errorf("proxy must have a host and port: %q", rawURL)
+	}
+	if err := checkInput(u.Host); err != nil {
+		return 0, "", err
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return proxyHTTP, u.Host, nil
+	case "https":
+		return proxyHTTPS, u.Host, nil
+	case "ftp":
+		return proxyFTP, u.Host, nil
+	case "socks", "socks4", "socks4a":
+		if u.User != nil {
+			return proxySOCKS, u.User.Username() + "@" + u.Host, nil
+		}
+		return proxySOCKS, u.Host, nil
+	default:
+		return 0, "", fmt.Errorf("unsupported proxy scheme: %q", u.Scheme)
+	}
+}
+
+// WithProxy routes requests through the proxy at the given URL.
+// The URL scheme selects the proxy setting: http, https, ftp, or socks.
+// WithProxy may be given once for each scheme.
+func WithProxy(rawURL string) Option {
+	return func(o *options) error {
+		kind, value, err := parseProxy(rawURL)
+		if err != nil {
+			return err
+		}
+		o.args = append(o.args, proxyFlags[kind], value)
+		return nil
+	}
+}
+
+// SetProxy sets the proxy for the scheme of the given URL through the
+// Setup→Network options→Proxies dialog. It applies to subsequent requests.
+func (b *Browser) SetProxy(rawURL string) error {
+	return b.SetProxyContext(context.Background(), rawURL)
+}
+
+// SetProxyContext is like SetProxy but bounds waits by ctx.
+func (b *Browser) SetProxyContext(ctx context.Context, rawURL string) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	kind, value, err := parseProxy(rawURL)
+	if err != nil {
+		return err
+	}
+	if err := checkInput(value); err != nil {
+		return err
+	}
+	defer b.closeMenu()
+	if err := b.OpenMenuContext(ctx, "Setup", "Network options"); err != nil {
+		return err
+	}
+	b.menuName = menuProxies
+	// The Proxies button follows the network fields.
+	b.c.Send("p")
+	if _, err := b.expectString(proxiesDialog); err != nil {
+		return err
+	}
+	// Focus the field, replace its value and submit the dialog.
+	b.c.Send(strings.Repeat("\t", int(kind)) + "\025" + value + "\n") // Tab, ^U, Enter
+	return nil
+}
@@ -0,0 +1,127 @@
+package links2
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Netflix/go-expect"
+)
+
+// WithConsoleOptions passes opts to the go-expect console links2 runs on,
+// after the Browser's own, e.g. to set a default read timeout or add a
+// logger. Options which replace the console's output or its observers
+// would keep the screen model from being updated, so only add to them.
+// The options also apply to the console of WithPTY.
+func WithConsoleOptions(opts ...expect.ConsoleOpt) Option {
+	return func(o *options) error {
+		o.consoleOpts = append(o.consoleOpts, opts...)
+		return nil
+	}
+}
+
+// WithPTY runs links2 on the pseudo-terminal returned by open instead of one
+// opened by go-expect, e.g. on platforms where creack/pty misbehaves. open
+// returns the controlling side, which the Browser reads output from and
+// sends keys to, and the terminal links2 runs on. Both are closed by Close.
+//
+// The Stdouts, Closers and SendObservers of WithConsoleOptions apply to the
+// console; its other options are ignored.
+func WithPTY(open func() (pty, tty *os.File, err error)) Option {
+	return func(o *options) error {
+		o.pty = open
+		return nil
+	}
+}
+
+// ptyConsole is the Console of WithPTY. Output is copied from the pty to a
+// pipe, which supports read deadlines, as go-expect does.
+type ptyConsole struct {
+	opts     expect.ConsoleOpts
+	pty, tty *os.File
+	out      *os.File // out is the read end of the pipe.
+}
+
+func newPTYConsole(open func() (pty, tty *os.File, err error), opts ...expect.ConsoleOpt) (*ptyConsole, error) {
+	var o expect.ConsoleOpts
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return nil, err
+		}
+	}
+	pty, tty, err := open()
+	if err != nil {
+		return nil, err
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		pty.Close()
+		tty.Close()
+		return nil, err
+	}
+	go func() {
+		io.Copy(w, pty)
+		w.Close()
+	}()
+	return &ptyConsole{opts: o, pty: pty, tty: tty, out: r}, nil
+}
+
+func (c *ptyConsole) Send(s string) (int, error) {
+	n, err := io.WriteString(c.pty, s)
+	for _, obs := range c.opts.SendObservers {
+		obs(s, n, err)
+	}
+	return n, err
+}
+
+// Expect reads output until a matcher of opts matches it or a read fails,
+// copying the output to the console's Stdouts.
+func (c *ptyConsole) Expect(opts ...expect.ExpectOpt) (string, error) {
+	var eo expect.ExpectOpts
+	for _, opt := range opts {
+		if err := opt(&eo); err != nil {
+			return "", err
+		}
+	}
+	var (
+		buf bytes.Buffer
+		p   [4096]byte
+	)
+	for {
+		deadline := time.Time{}
+		if eo.ReadTimeout != nil {
+			deadline = time.Now().Add(*eo.ReadTimeout)
+		}
+		if err := c.out.SetReadDeadline(deadline); err != nil {
+			return buf.String(), err
+		}
+		n, err := c.out.Read(p[:])
+		if n > 0 {
+			buf.Write(p[:n])
+			for _, w := range c.opts.Stdouts {
+				w.Write(p[:n])
+			}
+			if eo.Match(&buf) != nil {
+				return buf.String(), nil
+			}
+		}
+		if err != nil {
+			if eo.Match(err) != nil {
+				return buf.String(), nil
+			}
+			return buf.String(), err
+		}
+	}
+}
+
+func (c *ptyConsole) Tty() *os.File { return c.tty }
+
+func (c *ptyConsole) Close() error {
+	err := errors.Join(c.tty.Close(), c.pty.Close(), c.out.Close())
+	for _, cl := range c.opts.Closers {
+		err = errors.Join(err, cl.Close())
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// maxResyncTries bounds how often resync opens the dropdown menu.
+const maxResyncTries = 3
+
+// Raw calls fn with exclusive use of the Console links2 runs on, for what
+// the Browser's operations don't cover. Other operations wait until fn
+// returns. Expect waits on the output read by the Browser, bounded by the
+// Menu timeout unless fn gives one.
+//
+// The Browser can't tell what fn left on the screen, so once fn returns it
+// resyncs: it presses the keys of ActionMenu until the dropdown menu opens,
+// which also dismisses what fn left open, and closes the menu again. fn must
+// leave links2 in a state the resync recognizes: the document view, or at
+// most two nested menus or dialogs closed by those keys. It must not leave
+// keys half sent, links2 exited or another program running in the
+// terminal, and should leave the document as it found it, e.g. not toggle
+// the source view. If fn fails, the next operation resyncs instead.
+func (b *Browser) Raw(fn func(c Console) error) error {
+	return b.RawContext(context.Background(), fn)
+}
+
+// RawContext is like Raw but bounds the resync waits by ctx. If fn fails,
+// its error is returned.
+func (b *Browser) RawContext(ctx context.Context, fn func(c Console) error) error {
+	_, done := b.begin(ctx)
+	defer done()
+	if b.s == stateUndefined {
+		return ErrNotStarted
+	}
+	if err := b.checkExited(); err != nil {
+		return err
+	}
+	b.s = stateDirty
+	b.menuName = ""
+	if err := fn(readerConsole{Console: b.c, b: b}); err != nil {
+		return err
+	}
+	return b.resync()
+}
+
+// resync brings the state in line with the screen after keys the Browser
+// didn't send, by opening and closing the dropdown menu. The keys of
+// ActionMenu close a menu or dialog left open, so they're repeated until
+// the menu opens.
+func (b *Browser) resync() error {
+	keys, err := b.keys(ActionMenu)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < maxResyncTries; i++ {
+		if _, err := b.drain(); err != nil {
+			return err
+		}
+		// Error dialogs drawn by keys the Browser didn't send aren't ours.
+		b.errDialogs.take()
+		b.c.Send(keys)
+		_, err := b.expect(b.timeouts.Menu, b.match(dropdownMenu))
+		if err == nil {
+			b.s, b.menuName = stateMenu, menuDropdown
+			return b.closeMenu()
+		}
+		if !errors.Is(err, ErrTimeout) {
+			return err
+		}
+	}
+	return fmt.Errorf("resync: %w", ErrMenuOpen)
+}
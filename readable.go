@@ -0,0 +1,197 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+)
+
+// ReadableText returns the main content of the current document as plain
+// text, one paragraph per line group, leaving out navigation, headers,
+// footers, sidebars and other boilerplate.
+//
+// The document source is scored with a readability heuristic: paragraphs
+// with long text and few links score their containing elements, and the
+// paragraphs of the best scoring element are returned.
+func (b *Browser) ReadableText() (string, error) {
+	return b.ReadableTextContext(context.Background())
+}
+
+// ReadableTextContext is like ReadableText but bounds waits by ctx.
+func (b *Browser) ReadableTextContext(ctx context.Context) (string, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	var src bytes.Buffer
+	if err := b.writeSaved(&src, func(path string) error { return b.saveSource(ctx, path) }); err != nil {
+		return "", err
+	}
+	return readableText(src.String()), nil
+}
+
+// boilerplateTags are the elements which are never main content.
+var boilerplateTags = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true,
+	"nav": true, "header": true, "footer": true, "aside": true, "form": true,
+	"button": true, "select": true, "textarea": true, "svg": true, "iframe": true,
+}
+
+// boilerplateClass matches the class or id of elements which are unlikely
+// to be main content.
+var boilerplateClass = regexp.MustCompile(`(?i)\b(nav|menu|header|footer|sidebar|comment|share|social|related|promo|banner|advert|ads?|cookie|breadcrumbs?|pagination|subscribe|popup|modal)\b`)
+
+// containerTags are the elements which may hold the main content.
+var containerTags = map[string]bool{
+	"body": true, "div": true, "article": true, "main": true, "section": true, "td": true,
+}
+
+// voidTags are the elements which have no end tag.
+var voidTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true,
+	"img": true, "input": true, "link": true, "meta": true, "source": true,
+	"track": true, "wbr": true,
+}
+
+// rdNode is an element of the document tree built for readableText.
+type rdNode struct {
+	tag    string
+	parent int // parent is the index of the parent node, or -1.
+	skip   bool
+	score  float64
+}
+
+// rdPara is a paragraph of text.
+type rdPara struct {
+	owner int // owner is the index of the innermost element holding the text.
+	text  strings.Builder
+	links int // links is the length of the text within links.
+}
+
+// readableText extracts the main content of an HTML document.
+func readableText(src string) string {
+	var (
+		nodes = []rdNode{{tag: "#root", parent: -1}}
+		stack = []int{0}
+		paras []*rdPara
+		cur   *rdPara
+		inA   int
+	)
+	top := func() int { return stack[len(stack)-1] }
+	flush := func() {
+		if cur != nil && strings.TrimSpace(cur.text.String()) != "" {
+			paras = append(paras, cur)
+		}
+		cur = nil
+	}
+	for _, tok := range tokenize(src) {
+		switch tok.kind {
+		case startToken:
+			if !inlineTags[tok.tag] {
+				flush()
+			}
+			if tok.tag == "a" {
+				inA++
+			}
+			if voidTags[tok.tag] {
+				continue
+			}
+			parent := top()
+			skip := nodes[parent].skip || boilerplateTags[tok.tag] ||
+				boilerplateClass.MatchString(tok.attrs["class"]+" "+tok.attrs["id"]) ||
+				tok.attrs["role"] == "navigation"
+			nodes = append(nodes, rdNode{tag: tok.tag, parent: parent, skip: skip})
+			stack = append(stack, len(nodes)-1)
+		case endToken:
+			if !inlineTags[tok.tag] {
+				flush()
+			}
+			if tok.tag == "a" && inA > 0 {
+				inA--
+			}
+			for i := len(stack) - 1; i > 0; i-- {
+				if nodes[stack[i]].tag == tok.tag {
+					stack = stack[:i]
+					break
+				}
+			}
+		case textToken:
+			if nodes[top()].skip {
+				continue
+			}
+			text := strings.Join(strings.Fields(tok.text), " ")
+			if text == "" {
+				continue
+			}
+			if cur == nil {
+				cur = &rdPara{owner: top()}
+			} else {
+				cur.text.WriteByte(' ')
+			}
+			cur.text.WriteString(text)
+			if inA > 0 {
+				cur.links += len(text)
+			}
+		}
+	}
+	flush()
+
+	container := func(i int) int {
+		for i > 0 && !containerTags[nodes[i].tag] {
+			i = nodes[i].parent
+		}
+		return i
+	}
+	for _, p := range paras {
+		n := len(p.text.String())
+		if n < 25 {
+			continue
+		}
+		score := (1 + float64(strings.Count(p.text.String(), ",")) + min(float64(n)/100, 3)) * (1 - p.density())
+		c := container(p.owner)
+		nodes[c].score += score
+		if c > 0 {
+			nodes[container(nodes[c].parent)].score += score / 2
+		}
+	}
+	best := 0
+	for i, n := range nodes {
+		if n.score > nodes[best].score {
+			best = i
+		}
+	}
+	within := func(i int) bool {
+		for ; i >= 0; i = nodes[i].parent {
+			if i == best {
+				return true
+			}
+		}
+		return false
+	}
+	var out []string
+	for _, p := range paras {
+		if within(p.owner) && p.density() < 0.5 {
+			out = append(out, p.text.String())
+		}
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, "\n\n") + "\n"
+}
+
+// density returns the fraction of the text of p within links.
+func (p *rdPara) density() float64 {
+	n := p.text.Len()
+	if n == 0 {
+		return 0
+	}
+	return float64(p.links) / float64(n)
+}
+
+// inlineTags are the elements which don't break a paragraph.
+var inlineTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "bdi": true, "cite": true, "code": true,
+	"em": true, "i": true, "kbd": true, "mark": true, "q": true, "s": true,
+	"small": true, "span": true, "strong": true, "sub": true, "sup": true,
+	"time": true, "u": true, "var": true, "img": true, "font": true,
+}
@@ -0,0 +1,206 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Netflix/go-expect"
+)
+
+// readTimeout bounds each read of the output reader, so that a Console
+// which returns from Expect early doesn't stall it.
+const readTimeout = 10 * time.Second
+
+// outputReader is the single reader of a Console. A goroutine reads its
+// output as it's drawn, which the Console copies to the screen model and to
+// the outputReader, so the screen is always current and waits match the
+// output read so far instead of each reading the Console.
+//
+// Waits consume the output they match, as go-expect does, so the next wait
+// doesn't match it again.
+type outputReader struct {
+	mu      sync.Mutex
+	buf     []byte        // buf is the output not yet consumed by a wait.
+	err     error         // err ended the reads, if they ended.
+	changed chan struct{} // changed is closed and replaced when buf grows or err is set.
+	done    chan struct{} // done is closed when the reads end.
+}
+
+func newOutputReader() *outputReader {
+	return &outputReader{changed: make(chan struct{}), done: make(chan struct{})}
+}
+
+// Write appends output copied by the Console.
+func (r *outputReader) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buf = append(r.buf, p...)
+	r.notify()
+	return len(p), nil
+}
+
+func (r *outputReader) notify() {
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// run reads the output of c until a read fails, e.g. as c is closed.
+func (r *outputReader) run(c Console) {
+	defer close(r.done)
+	for {
+		_, err := c.Expect(expect.WithTimeout(readTimeout))
+		if err == nil || errors.Is(err, os.ErrDeadlineExceeded) {
+			continue
+		}
+		r.mu.Lock()
+		r.err = err
+		r.notify()
+		r.mu.Unlock()
+		return
+	}
+}
+
+// state returns the output not yet consumed, the error which ended the
+// reads, if any, and a channel which is closed once either changes.
+func (r *outputReader) state() ([]byte, error, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf, r.err, r.changed
+}
+
+// consume consumes the first n bytes of output and returns them.
+func (r *outputReader) consume(n int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := string(r.buf[:n])
+	r.buf = r.buf[n:]
+	return s
+}
+
+// consumeAll consumes all output read so far and returns it.
+func (r *outputReader) consumeAll() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := string(r.buf)
+	r.buf = nil
+	return s
+}
+
+// match waits for a matcher of eo to match the output, bounded by timeout
+// without output and by ctx. Like go-expect, the shortest matching output is
+// consumed and returned. On failure, all output read is consumed and
+// returned with the error.
+//
+// Matchers are assumed to match all output which starts with output they
+// match, as the string and regexp matchers do, so the shortest match is
+// found by a binary search instead of matching each rune as it's read.
+func (r *outputReader) match(ctx context.Context, timeout time.Duration, eo expect.ExpectOpts) (string, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	scanned, seen := 0, -1
+	for {
+		buf, err, changed := r.state()
+		if seen >= 0 && len(buf) > seen {
+			// The output grew, which restarts the timeout.
+			resetTimer(timer, timeout)
+		}
+		seen = len(buf)
+		if n, ok := shortestMatch(eo, buf, scanned); ok {
+			return r.consume(n), nil
+		}
+		scanned = len(buf)
+		if err != nil {
+			if eo.Match(err) != nil {
+				return r.consumeAll(), nil
+			}
+			return r.consumeAll(), err
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return r.consumeAll(), fmt.Errorf("%w: %w", ErrTimeout, os.ErrDeadlineExceeded)
+		case <-ctx.Done():
+			return r.consumeAll(), ctx.Err()
+		}
+	}
+}
+
+// shortestMatch returns the length of the shortest output of buf longer
+// than from which a matcher of eo matches.
+func shortestMatch(eo expect.ExpectOpts, buf []byte, from int) (int, bool) {
+	matches := func(n int) bool { return eo.Match(bytes.NewBuffer(buf[:n:n])) != nil }
+	if from >= len(buf) || !matches(len(buf)) {
+		return 0, false
+	}
+	lo, hi := from+1, len(buf)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if matches(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	// Complete the last rune.
+	for lo < len(buf) && !utf8.RuneStart(buf[lo]) {
+		lo++
+	}
+	return lo, true
+}
+
+// drain waits until there was no output for quiet and consumes the output.
+func (r *outputReader) drain(quiet time.Duration) (string, error) {
+	timer := time.NewTimer(quiet)
+	defer timer.Stop()
+	for {
+		_, err, changed := r.state()
+		if err != nil {
+			return r.consumeAll(), err
+		}
+		select {
+		case <-changed:
+			resetTimer(timer, quiet)
+		case <-timer.C:
+			return r.consumeAll(), nil
+		}
+	}
+}
+
+// resetTimer resets t to fire after d, discarding a pending fire.
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
+
+// readerConsole is the Console given to Raw. Its Expect waits on the output
+// reader instead of reading the Console, bounded by the Menu timeout unless
+// a timeout is given.
+type readerConsole struct {
+	Console
+	b *Browser
+}
+
+func (c readerConsole) Expect(opts ...expect.ExpectOpt) (string, error) {
+	var eo expect.ExpectOpts
+	for _, opt := range opts {
+		if err := opt(&eo); err != nil {
+			return "", err
+		}
+	}
+	timeout := c.b.timeouts.Menu
+	if eo.ReadTimeout != nil {
+		timeout = *eo.ReadTimeout
+	}
+	return c.b.expect(timeout, opts...)
+}
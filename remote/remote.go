@@ -0,0 +1,230 @@
+// Package remote exposes Browsers over a minimal WebDriver-like HTTP API, so
+// clients in any language can drive links2 sessions.
+//
+// Requests and responses are JSON. Successful responses hold the result in
+// "value"; errors hold {"error": code, "message": text} in "value".
+//
+//	POST   /session                     open a Browser, value is {"sessionId": id}
+//	DELETE /session/{id}                quit it
+//	POST   /session/{id}/url            navigate to {"url": url}
+//	GET    /session/{id}/url            current URL
+//	POST   /session/{id}/back           go back
+//	GET    /session/{id}/title          document title
+//	GET    /session/{id}/links          links of the document
+//	POST   /session/{id}/links/{index}  follow the link with the given index
+//	GET    /session/{id}/text           formatted text of the whole document
+//	GET    /session/{id}/screen         text of the screen
+//	GET    /session/{id}/screenshot     base64 PNG screenshot (graphics mode only)
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/ajzaff/links2"
+)
+
+// Server serves the remote API. Sessions are opened with the options given to
+// NewServer.
+type Server struct {
+	opts []links2.Option
+	mux  *http.ServeMux
+
+	mu       sync.Mutex
+	sessions map[string]*links2.Browser
+}
+
+// NewServer returns a Server opening Browsers with opts.
+func NewServer(opts ...links2.Option) *Server {
+	s := &Server{opts: opts, mux: http.NewServeMux(), sessions: make(map[string]*links2.Browser)}
+	s.mux.HandleFunc("POST /session", s.newSession)
+	s.mux.HandleFunc("DELETE /session/{id}", s.session(deleteSession))
+	s.mux.HandleFunc("POST /session/{id}/url", s.session(navigate))
+	s.mux.HandleFunc("GET /session/{id}/url", s.session(currentURL))
+	s.mux.HandleFunc("POST /session/{id}/back", s.session(back))
+	s.mux.HandleFunc("GET /session/{id}/title", s.session(title))
+	s.mux.HandleFunc("GET /session/{id}/links", s.session(links))
+	s.mux.HandleFunc("POST /session/{id}/links/{index}", s.session(followLink))
+	s.mux.HandleFunc("GET /session/{id}/text", s.session(text))
+	s.mux.HandleFunc("GET /session/{id}/screen", s.session(screen))
+	s.mux.HandleFunc("GET /session/{id}/screenshot", s.session(screenshot))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) { s.mux.ServeHTTP(w, r) }
+
+// Close closes all sessions.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for id, b := range s.sessions {
+		errs = append(errs, b.Close())
+		delete(s.sessions, id)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Server) newSession(w http.ResponseWriter, r *http.Request) {
+	b := new(links2.Browser)
+	if err := b.OpenContext(context.Background(), s.opts...); err != nil {
+		writeError(w, http.StatusInternalServerError, "session not created", err)
+		return
+	}
+	var id [16]byte
+	rand.Read(id[:])
+	sid := hex.EncodeToString(id[:])
+	s.mu.Lock()
+	s.sessions[sid] = b
+	s.mu.Unlock()
+	writeValue(w, map[string]string{"sessionId": sid})
+}
+
+// handler handles a request for a session, returning the response value.
+type handler func(s *Server, id string, b *links2.Browser, r *http.Request) (any, error)
+
+// session looks up the session of a request for h.
+func (s *Server) session(h handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		s.mu.Lock()
+		b := s.sessions[id]
+		s.mu.Unlock()
+		if b == nil {
+			writeError(w, http.StatusNotFound, "invalid session id", fmt.Errorf("no session %q", id))
+			return
+		}
+		v, err := h(s, id, b, r)
+		if err != nil {
+			status, code := http.StatusInternalServerError, "unknown error"
+			var re *requestError
+			if errors.As(err, &re) {
+				status, code = http.StatusBadRequest, "invalid argument"
+			} else if errors.Is(err, links2.ErrNoLink) {
+				status, code = http.StatusNotFound, "no such element"
+			} else if errors.Is(err, links2.ErrTimeout) {
+				code = "timeout"
+			}
+			writeError(w, status, code, err)
+			return
+		}
+		writeValue(w, v)
+	}
+}
+
+// requestError is an error in the request rather than the browser.
+type requestError struct{ err error }
+
+func (e *requestError) Error() string { return e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+func deleteSession(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	s.mu.Lock()
+	delete(s.sessions, id)
+	s.mu.Unlock()
+	if err := b.QuitContext(r.Context()); err != nil && !errors.Is(err, links2.ErrNotStarted) {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func navigate(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	var req struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	_, err := b.NavigateContext(r.Context(), req.URL)
+	return nil, err
+}
+
+func currentURL(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	u, err := b.CurrentURLContext(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return u.String(), nil
+}
+
+func back(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	_, err := b.BackLinkContext(r.Context())
+	return nil, err
+}
+
+func title(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	return b.TitleContext(r.Context())
+}
+
+func links(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	links, err := b.LinksContext(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	type link struct {
+		Text  string `json:"text"`
+		URL   string `json:"url"`
+		Index int    `json:"index"`
+	}
+	v := make([]link, len(links))
+	for i, l := range links {
+		v[i] = link{l.Text, l.URL, l.Index}
+	}
+	return v, nil
+}
+
+func followLink(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	i, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil {
+		return nil, &requestError{fmt.Errorf("invalid link index: %q", r.PathValue("index"))}
+	}
+	n := 0
+	return nil, b.FollowLinkMatchingContext(r.Context(), func(links2.Link) bool {
+		n++
+		return n-1 == i
+	})
+}
+
+func text(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	return b.PageTextContext(r.Context())
+}
+
+func screen(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	return b.Screen(), nil
+}
+
+func screenshot(s *Server, id string, b *links2.Browser, r *http.Request) (any, error) {
+	img, err := b.Screenshot()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func writeValue(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"value": v})
+}
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"value": map[string]string{
+		"error":   code,
+		"message": err.Error(),
+	}})
+}
@@ -0,0 +1,239 @@
+package links2
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultCache caches the formatted text and source of documents by URL, so
+// that PageTextAt and SourceAt can skip driving links2 for documents
+// extracted recently. It's safe for concurrent use and may be shared by the
+// Browsers of a Pool.
+//
+// Results expire after the cache's TTL. Once the cache holds its maximum
+// number of results, the least recently used one is evicted.
+type ResultCache struct {
+	ttl time.Duration
+	max int
+
+	mu      sync.Mutex
+	results map[resultKey]*list.Element
+	lru     list.List // lru holds the *cachedResult values, most recent first.
+	stats   CacheStats
+}
+
+// CacheStats counts the lookups and removals of a ResultCache.
+type CacheStats struct {
+	Hits, Misses int64
+	Evictions    int64 // Evictions counts results evicted to make room.
+	Expirations  int64 // Expirations counts expired results removed.
+}
+
+// resultKind is the kind of a cached result.
+type resultKind int
+
+const (
+	resultText resultKind = iota
+	resultSource
+)
+
+type resultKey struct {
+	url  string
+	kind resultKind
+}
+
+type cachedResult struct {
+	key     resultKey
+	data    []byte
+	expires time.Time
+}
+
+// NewResultCache returns a ResultCache holding up to max results for ttl.
+func NewResultCache(max int, ttl time.Duration) *ResultCache {
+	if max <= 0 || ttl <= 0 {
+		panic("links2: NewResultCache: max and ttl must be positive")
+	}
+	return &ResultCache{ttl: ttl, max: max, results: make(map[resultKey]*list.Element)}
+}
+
+// WithResultCache serves PageTextAt and SourceAt from c.
+func WithResultCache(c *ResultCache) Option {
+	return func(o *options) error {
+		o.resultCache = c
+		return nil
+	}
+}
+
+// Stats returns the statistics of c.
+func (c *ResultCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Len returns the number of results held by c, including expired results
+// not yet removed.
+func (c *ResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Invalidate removes the results of rawURL.
+func (c *ResultCache) Invalidate(rawURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	u := resultURL(rawURL)
+	for _, kind := range []resultKind{resultText, resultSource} {
+		if e, ok := c.results[resultKey{u, kind}]; ok {
+			c.remove(e)
+		}
+	}
+}
+
+// Purge removes all results.
+func (c *ResultCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = make(map[resultKey]*list.Element)
+	c.lru.Init()
+}
+
+// get returns a copy of the result k, so callers can't modify the cached
+// result shared with other Browsers.
+func (c *ResultCache) get(k resultKey) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.results[k]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	r := e.Value.(*cachedResult)
+	if time.Now().After(r.expires) {
+		c.remove(e)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	c.stats.Hits++
+	return bytes.Clone(r.data), true
+}
+
+func (c *ResultCache) put(k resultKey, data []byte) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.results[k]; ok {
+		c.remove(e)
+	}
+	r := &cachedResult{key: k, data: bytes.Clone(data), expires: time.Now().Add(c.ttl)}
+	c.results[k] = c.lru.PushFront(r)
+	for c.lru.Len() > c.max {
+		oldest := c.lru.Back()
+		if time.Now().After(oldest.Value.(*cachedResult).expires) {
+			c.stats.Expirations++
+		} else {
+			c.stats.Evictions++
+		}
+		c.remove(oldest)
+	}
+}
+
+func (c *ResultCache) remove(e *list.Element) {
+	delete(c.results, e.Value.(*cachedResult).key)
+	c.lru.Remove(e)
+}
+
+// resultURL returns the URL results of rawURL are cached by: the URL
+// Navigate loads for it with the host lowercased, an empty HTTP path made
+// "/" and the default port and fragment removed, so different spellings of
+// a document's URL share its results.
+func resultURL(rawURL string) string {
+	u, err := parseNavigateURL(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.Host = strings.ToLower(u.Host)
+	switch port := u.Port(); {
+	case u.Scheme == "http" && port == "80", u.Scheme == "https" && port == "443":
+		u.Host = strings.TrimSuffix(u.Host, ":"+port)
+	}
+	if u.Path == "" && (u.Scheme == "http" || u.Scheme == "https") {
+		u.Path = "/"
+	}
+	u.Fragment, u.RawFragment = "", ""
+	return u.String()
+}
+
+// resultCache returns the ResultCache of WithResultCache or nil.
+func (b *Browser) resultCache() *ResultCache {
+	if b.opts == nil {
+		return nil
+	}
+	return b.opts.resultCache
+}
+
+// PageTextAt navigates to rawURL and returns its formatted text, like
+// PageText. With WithResultCache, a fresh cached text is returned instead,
+// in which case the current document is left as it is.
+func (b *Browser) PageTextAt(rawURL string) (string, error) {
+	return b.PageTextAtContext(context.Background(), rawURL)
+}
+
+// PageTextAtContext is like PageTextAt but bounds waits by ctx.
+func (b *Browser) PageTextAtContext(ctx context.Context, rawURL string) (string, error) {
+	data, err := b.cachedExtract(ctx, rawURL, resultText, func(ctx context.Context) ([]byte, error) {
+		text, err := b.formattedDocument(ctx)
+		return []byte(text), err
+	})
+	return string(data), err
+}
+
+// SourceAt navigates to rawURL and returns its source, like WriteSource,
+// served from the cache of WithResultCache like PageTextAt.
+func (b *Browser) SourceAt(rawURL string) ([]byte, error) {
+	return b.SourceAtContext(context.Background(), rawURL)
+}
+
+// SourceAtContext is like SourceAt but bounds waits by ctx.
+func (b *Browser) SourceAtContext(ctx context.Context, rawURL string) ([]byte, error) {
+	return b.cachedExtract(ctx, rawURL, resultSource, func(ctx context.Context) ([]byte, error) {
+		var src bytes.Buffer
+		err := b.writeSaved(&src, func(path string) error { return b.saveSource(ctx, path) })
+		return src.Bytes(), err
+	})
+}
+
+// cachedExtract returns the cached result of kind for rawURL, or navigates
+// to rawURL and caches what extract returns.
+func (b *Browser) cachedExtract(ctx context.Context, rawURL string, kind resultKind, extract func(context.Context) ([]byte, error)) ([]byte, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	cache := b.resultCache()
+	k := resultKey{resultURL(rawURL), kind}
+	if data, ok := cache.get(k); ok {
+		b.log.Debug("result cache hit", "url", rawURL)
+		return data, nil
+	}
+	if _, err := b.NavigateContext(ctx, rawURL); err != nil {
+		return nil, err
+	}
+	data, err := extract(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("extract %s: %w", rawURL, err)
+	}
+	cache.put(k, data)
+	return data, nil
+}
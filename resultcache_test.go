@@ -0,0 +1,93 @@
+package links2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResultCacheLRU(t *testing.T) {
+	c := NewResultCache(2, time.Hour)
+	c.put(resultKey{"a", resultText}, []byte("a"))
+	c.put(resultKey{"b", resultText}, []byte("b"))
+	if _, ok := c.get(resultKey{"a", resultText}); !ok {
+		t.Fatal("a not cached")
+	}
+	c.put(resultKey{"c", resultText}, []byte("c")) // Evicts b, the least recently used.
+	if _, ok := c.get(resultKey{"b", resultText}); ok {
+		t.Error("b not evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if data, ok := c.get(resultKey{k, resultText}); !ok || string(data) != k {
+			t.Errorf("get(%q) = %q, %v", k, data, ok)
+		}
+	}
+	want := CacheStats{Hits: 3, Misses: 1, Evictions: 1}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if n := c.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+}
+
+func TestResultCacheTTL(t *testing.T) {
+	c := NewResultCache(2, time.Millisecond)
+	k := resultKey{"a", resultSource}
+	c.put(k, []byte("a"))
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.get(k); ok {
+		t.Fatal("expired result returned")
+	}
+	want := CacheStats{Misses: 1, Expirations: 1}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len() = %d, want 0", n)
+	}
+}
+
+func TestResultCacheCopies(t *testing.T) {
+	c := NewResultCache(1, time.Hour)
+	k := resultKey{"a", resultSource}
+	data := []byte("abc")
+	c.put(k, data)
+	data[0] = 'x'
+	got, _ := c.get(k)
+	got[1] = 'x'
+	if again, _ := c.get(k); string(again) != "abc" {
+		t.Errorf("cached result modified to %q", again)
+	}
+}
+
+func TestResultCacheInvalidate(t *testing.T) {
+	c := NewResultCache(4, time.Hour)
+	c.put(resultKey{resultURL("http://example.com/"), resultText}, []byte("text"))
+	c.put(resultKey{resultURL("http://example.com/"), resultSource}, []byte("source"))
+	c.put(resultKey{resultURL("http://example.org/"), resultText}, []byte("other"))
+	c.Invalidate("HTTP://EXAMPLE.com")
+	if n := c.Len(); n != 1 {
+		t.Errorf("Len() after Invalidate = %d, want 1", n)
+	}
+	c.Purge()
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len() after Purge = %d, want 0", n)
+	}
+}
+
+func TestResultURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"http://example.com/a", "http://example.com/a"},
+		{"example.com:8080/a", "http://example.com:8080/a"},
+		{"HTTP://Example.COM", "http://example.com/"},
+		{"http://example.com:80/a#top", "http://example.com/a"},
+		{"https://example.com:443", "https://example.com/"},
+		{"https://example.com:8443/", "https://example.com:8443/"},
+		{"/tmp/page.html#s", "file:///tmp/page.html"},
+	}
+	for _, tc := range tests {
+		if got := resultURL(tc.in); got != tc.want {
+			t.Errorf("resultURL(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BackoffFunc returns how long to wait before retry number attempt, starting
+// at 1.
+type BackoffFunc func(attempt int) time.Duration
+
+// ExponentialBackoff returns a BackoffFunc waiting base before the first
+// retry and doubling the wait for each retry after it, up to max.
+func ExponentialBackoff(base, max time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		return min(d, max)
+	}
+}
+
+// WithRetry makes Navigate try a URL up to attempts times when links2 fails
+// to look up the host or to load the page, waiting backoff between attempts.
+// Other errors, like SSL failures and missing files, are not retried.
+func WithRetry(attempts int, backoff BackoffFunc) Option {
+	return func(o *options) error {
+		if attempts < 1 {
+			return fmt.Errorf("retry: invalid attempts: %d", attempts)
+		}
+		o.retries, o.backoff = attempts, backoff
+		return nil
+	}
+}
+
+// retryable reports whether a navigation which failed with err may succeed
+// when tried again.
+func retryable(err error) bool {
+	if errors.Is(err, ErrNavigationAborted) {
+		return false
+	}
+	return errors.Is(err, ErrHostNotFound) || errors.Is(err, ErrLoading)
+}
+
+// navigateRetry navigates to rawURL, retrying as configured by WithRetry.
+// The error dialog of a failed attempt is closed before the next one.
+func (b *Browser) navigateRetry(rawURL string) (NavigateResult, error) {
+	attempts := 1
+	var backoff BackoffFunc
+	if b.opts != nil && b.opts.retries > 0 {
+		attempts, backoff = b.opts.retries, b.opts.backoff
+	}
+	for attempt := 1; ; attempt++ {
+		res, err := b.navigate(rawURL)
+		res.Attempts = attempt
+		if err == nil || attempt == attempts || !retryable(err) {
+			return res, err
+		}
+		b.log.Info("navigate retry", "url", res.urlString(), "attempt", attempt, "err", err)
+		if err := b.closeMenu(); err != nil {
+			return res, err
+		}
+		if backoff != nil {
+			if err := b.sleep(backoff(attempt)); err != nil {
+				return res, fmt.Errorf("navigate %s: %w", res.urlString(), err)
+			}
+		}
+	}
+}
+
+// sleep waits for d or until the operation context is done.
+func (b *Browser) sleep(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,14 @@
+//go:build grpc
+
+package rpc
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/ajzaff/links2/rpc/rpcpb"
+)
+
+// NewClient returns a client of the Browser service on conn.
+func NewClient(conn grpc.ClientConnInterface) rpcpb.BrowserClient {
+	return rpcpb.NewBrowserClient(conn)
+}
@@ -0,0 +1,11 @@
+// Package rpc serves links2 Browsers over gRPC using the service defined in
+// links2.proto, including streams of browser events and download progress.
+//
+// The gRPC code depends on google.golang.org/grpc and on the rpcpb package
+// generated from links2.proto, which isn't committed. It's only built with
+// the grpc build tag, so without the tag this package is empty and builds
+// with no dependencies. To build it, generate rpcpb first:
+//
+//	go generate ./rpc/rpcpb
+//	go build -tags grpc ./rpc
+package rpc
@@ -0,0 +1,9 @@
+// Package rpcpb holds the Go code generated from ../links2.proto for package
+// rpc. The generated files aren't committed; run
+//
+//	go generate ./rpc/rpcpb
+//
+// with protoc, protoc-gen-go and protoc-gen-go-grpc installed to create them.
+package rpcpb
+
+//go:generate protoc --proto_path=.. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative links2.proto
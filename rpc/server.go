@@ -0,0 +1,219 @@
+//go:build grpc
+
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ajzaff/links2"
+	"github.com/ajzaff/links2/rpc/rpcpb"
+)
+
+// Server implements the Browser service with Browsers acquired from a Pool.
+type Server struct {
+	rpcpb.UnimplementedBrowserServer
+
+	pool *links2.Pool
+
+	mu       sync.Mutex
+	sessions map[string]*links2.Browser
+}
+
+// NewServer returns a Server opening sessions with Browsers from pool.
+func NewServer(pool *links2.Pool) *Server {
+	return &Server{pool: pool, sessions: make(map[string]*links2.Browser)}
+}
+
+func (s *Server) browser(id string) (*links2.Browser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.sessions[id]
+	if b == nil {
+		return nil, status.Errorf(codes.NotFound, "no session %q", id)
+	}
+	return b, nil
+}
+
+func (s *Server) Open(ctx context.Context, req *rpcpb.OpenRequest) (*rpcpb.Session, error) {
+	b, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var id [16]byte
+	rand.Read(id[:])
+	sid := hex.EncodeToString(id[:])
+	s.mu.Lock()
+	s.sessions[sid] = b
+	s.mu.Unlock()
+	return &rpcpb.Session{Id: sid}, nil
+}
+
+func (s *Server) Close(ctx context.Context, req *rpcpb.Session) (*rpcpb.Empty, error) {
+	b, err := s.browser(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	delete(s.sessions, req.Id)
+	s.mu.Unlock()
+	s.pool.Release(b, nil)
+	return &rpcpb.Empty{}, nil
+}
+
+func (s *Server) Navigate(ctx context.Context, req *rpcpb.NavigateRequest) (*rpcpb.NavigateResponse, error) {
+	b, err := s.browser(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := b.NavigateContext(ctx, req.Url)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.NavigateResponse{DurationNanos: int64(res.Duration())}, nil
+}
+
+func (s *Server) Back(ctx context.Context, req *rpcpb.Session) (*rpcpb.Empty, error) {
+	b, err := s.browser(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := b.BackLinkContext(ctx); err != nil {
+		return nil, err
+	}
+	return &rpcpb.Empty{}, nil
+}
+
+func (s *Server) CurrentURL(ctx context.Context, req *rpcpb.Session) (*rpcpb.Text, error) {
+	b, err := s.browser(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	u, err := b.CurrentURLContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Text{Text: u.String()}, nil
+}
+
+func (s *Server) Links(ctx context.Context, req *rpcpb.Session) (*rpcpb.LinksResponse, error) {
+	b, err := s.browser(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	links, err := b.LinksContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &rpcpb.LinksResponse{}
+	for _, l := range links {
+		res.Links = append(res.Links, &rpcpb.Link{Text: l.Text, Url: l.URL, Index: int32(l.Index)})
+	}
+	return res, nil
+}
+
+func (s *Server) FollowLink(ctx context.Context, req *rpcpb.FollowLinkRequest) (*rpcpb.Empty, error) {
+	b, err := s.browser(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	err = b.FollowLinkMatchingContext(ctx, func(l links2.Link) bool { return l.Index == int(req.Index) })
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Empty{}, nil
+}
+
+func (s *Server) PageText(ctx context.Context, req *rpcpb.Session) (*rpcpb.Text, error) {
+	b, err := s.browser(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	text, err := b.PageTextContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Text{Text: text}, nil
+}
+
+func (s *Server) Screen(ctx context.Context, req *rpcpb.Session) (*rpcpb.Text, error) {
+	b, err := s.browser(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Text{Text: b.Screen()}, nil
+}
+
+func (s *Server) Events(req *rpcpb.Session, stream rpcpb.Browser_EventsServer) error {
+	b, err := s.browser(req.Id)
+	if err != nil {
+		return err
+	}
+	events := b.Events()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			msg := &rpcpb.Event{
+				Kind:          e.Kind.String(),
+				TimeUnixNanos: e.Time.UnixNano(),
+				Url:           e.URL,
+				Message:       e.Message,
+			}
+			if e.Err != nil {
+				msg.Error = e.Err.Error()
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) Download(req *rpcpb.DownloadRequest, stream rpcpb.Browser_DownloadServer) error {
+	b, err := s.browser(req.SessionId)
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	d, err := b.DownloadLinkContext(ctx, req.Path)
+	if err != nil {
+		return err
+	}
+	interval := time.Duration(req.IntervalMillis) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Second
+	}
+	for {
+		st, err := d.ProgressContext(ctx)
+		if err != nil {
+			return fmt.Errorf("download %s: %w", req.Path, err)
+		}
+		err = stream.Send(&rpcpb.DownloadProgress{
+			Url:      st.URL,
+			Received: st.Received,
+			Total:    st.Total,
+			Percent:  int32(st.Percent),
+			Done:     st.Done,
+		})
+		if err != nil || st.Done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package links2
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// SaveProgress is the progress of a document being saved to a file.
+type SaveProgress struct {
+	Path    string
+	Written int64 // Written is the size of the file so far.
+	Done    bool  // Done is set once the file is written.
+	Time    time.Time
+}
+
+// WithSaveProgress calls fn with the progress of documents saved by links2,
+// e.g. by SaveFormattedDocument or WriteSource: whenever the file grows and
+// once it's written. fn is called by the saving operation and must not use
+// the Browser.
+func WithSaveProgress(fn func(SaveProgress)) Option {
+	return func(o *options) error {
+		o.saveProgress = fn
+		return nil
+	}
+}
+
+// saveStream copies a file to w as links2 writes it, so that large
+// documents are passed on while they're saved. The stream ends once
+// waitFile sees links2 close the file, so pauses of a slow download don't
+// cut it short.
+type saveStream struct {
+	path string
+	w    io.Writer
+	f    *os.File
+	n    int64 // n is the number of bytes copied.
+}
+
+// copy copies the bytes written to the file since the last call.
+func (s *saveStream) copy() error {
+	if s.f == nil {
+		f, err := os.Open(s.path)
+		if err != nil {
+			return err
+		}
+		s.f = f
+	}
+	n, err := io.Copy(s.w, s.f)
+	s.n += n
+	return err
+}
+
+func (s *saveStream) close() {
+	if s.f != nil {
+		s.f.Close()
+	}
+}
+
+// reportSave reports the progress of the file at path to the WithSaveProgress
+// function, if any.
+func (b *Browser) reportSave(path string, written int64, done bool) {
+	if b.opts == nil || b.opts.saveProgress == nil {
+		return
+	}
+	b.opts.saveProgress(SaveProgress{Path: path, Written: written, Done: done, Time: time.Now()})
+}
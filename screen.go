@@ -0,0 +1,517 @@
+package links2
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Default terminal size links2 assumes when the PTY reports no size.
+const (
+	defaultCols = 80
+	defaultRows = 25
+)
+
+// Attr is a set of cell display attributes.
+type Attr uint8
+
+const (
+	AttrBold Attr = 1 << iota
+	AttrUnderline
+	AttrBlink
+	AttrReverse
+)
+
+// Color is one of the 8 ANSI colors or ColorDefault.
+type Color int8
+
+const ColorDefault Color = -1
+
+// Cell is a single character cell of the terminal screen.
+type Cell struct {
+	Rune rune
+	Attr Attr
+	FG   Color
+	BG   Color
+}
+
+var blankCell = Cell{Rune: ' ', FG: ColorDefault, BG: ColorDefault}
+
+// decGraphics maps the DEC special graphics charset to box-drawing runes.
+var decGraphics = map[byte]rune{
+	'j': '┘', 'k': '┐', 'l': '┌', 'm': '└', 'n': '┼',
+	'q': '─', 't': '├', 'u': '┤', 'v': '┴', 'w': '┬', 'x': '│',
+	'a': '▒', '`': '◆', '~': '·',
+}
+
+// screen is a minimal VT100/ANSI terminal emulator maintaining the 2D screen
+// buffer drawn by links2. It understands the subset of control sequences
+// links2 emits: cursor movement, erasure, scrolling, SGR attributes, DEC line
+// drawing, and the xterm window title.
+type screen struct {
+	mu         sync.Mutex
+	cols, rows int
+	cells      []Cell
+	x, y       int
+	savedX     int
+	savedY     int
+	pen        Cell
+	graphics   bool
+	top, bot   int // scroll region rows, inclusive
+	title      string
+	pending    []byte // incomplete sequence carried across writes
+}
+
+func newScreen(cols, rows int) *screen {
+	s := &screen{}
+	s.resize(cols, rows)
+	return s
+}
+
+func (s *screen) resize(cols, rows int) {
+	cells := make([]Cell, cols*rows)
+	for i := range cells {
+		cells[i] = blankCell
+	}
+	for y := 0; y < min(rows, s.rows); y++ {
+		copy(cells[y*cols:y*cols+min(cols, s.cols)], s.cells[y*s.cols:])
+	}
+	s.cols, s.rows, s.cells = cols, rows, cells
+	s.top, s.bot = 0, rows-1
+	s.x, s.y = min(s.x, cols-1), min(s.y, rows-1)
+	s.savedX, s.savedY = min(s.savedX, cols-1), min(s.savedY, rows-1)
+	s.pen = blankCell
+}
+
+// Write implements io.Writer by interpreting p as terminal output.
+func (s *screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buf := append(s.pending, p...)
+	s.pending = nil
+	for len(buf) > 0 {
+		n := s.step(buf)
+		if n == 0 {
+			// Incomplete sequence; wait for more output.
+			s.pending = append([]byte(nil), buf...)
+			break
+		}
+		buf = buf[n:]
+	}
+	return len(p), nil
+}
+
+// step interprets the first character or sequence in buf and returns the
+// number of bytes consumed, or 0 if buf holds an incomplete sequence.
+func (s *screen) step(buf []byte) int {
+	switch c := buf[0]; c {
+	case '\033':
+		return s.escape(buf)
+	case '\r':
+		s.x = 0
+	case '\n', '\v', '\f':
+		s.lineFeed()
+	case '\b':
+		s.x = max(s.x-1, 0)
+	case '\t':
+		s.x = min((s.x/8+1)*8, s.cols-1)
+	case 0x0e, 0x0f: // SO, SI
+		s.graphics = c == 0x0e
+	default:
+		if c < ' ' || c == 0x7f {
+			return 1
+		}
+		if !utf8.FullRune(buf) {
+			return 0
+		}
+		r, n := utf8.DecodeRune(buf)
+		if s.graphics && r < utf8.RuneSelf {
+			if g, ok := decGraphics[byte(r)]; ok {
+				r = g
+			}
+		}
+		s.put(r)
+		return n
+	}
+	return 1
+}
+
+func (s *screen) put(r rune) {
+	if s.x >= s.cols {
+		s.x = 0
+		s.lineFeed()
+	}
+	cell := s.pen
+	cell.Rune = r
+	s.cells[s.y*s.cols+s.x] = cell
+	s.x++
+}
+
+func (s *screen) lineFeed() {
+	if s.y == s.bot {
+		s.scrollUp(1)
+		return
+	}
+	s.y = min(s.y+1, s.rows-1)
+}
+
+// scrollUp scrolls the scroll region up n lines.
+func (s *screen) scrollUp(n int) { s.deleteLines(s.top, n) }
+
+// scrollDown scrolls the scroll region down n lines.
+func (s *screen) scrollDown(n int) { s.insertLines(s.top, n) }
+
+// deleteLines deletes n lines at row y, shifting up the rest of the scroll region.
+func (s *screen) deleteLines(y, n int) {
+	n = min(n, s.bot-y+1)
+	copy(s.cells[y*s.cols:(s.bot+1)*s.cols], s.cells[(y+n)*s.cols:(s.bot+1)*s.cols])
+	s.clear((s.bot+1-n)*s.cols, (s.bot+1)*s.cols)
+}
+
+// insertLines inserts n blank lines at row y, shifting down the rest of the scroll region.
+func (s *screen) insertLines(y, n int) {
+	n = min(n, s.bot-y+1)
+	copy(s.cells[(y+n)*s.cols:(s.bot+1)*s.cols], s.cells[y*s.cols:(s.bot+1-n)*s.cols])
+	s.clear(y*s.cols, (y+n)*s.cols)
+}
+
+// clear blanks the cells in [i, j) using the current background.
+func (s *screen) clear(i, j int) {
+	blank := blankCell
+	blank.BG = s.pen.BG
+	for ; i < j; i++ {
+		s.cells[i] = blank
+	}
+}
+
+// escape interprets the escape sequence at the start of buf.
+func (s *screen) escape(buf []byte) int {
+	if len(buf) < 2 {
+		return 0
+	}
+	switch buf[1] {
+	case '[':
+		return s.csi(buf)
+	case ']':
+		return s.osc(buf)
+	case '(', ')':
+		if len(buf) < 3 {
+			return 0
+		}
+		if buf[1] == '(' {
+			s.graphics = buf[2] == '0'
+		}
+		return 3
+	case '7':
+		s.savedX, s.savedY = s.x, s.y
+	case '8':
+		s.x, s.y = s.savedX, s.savedY
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.x = 0
+		s.lineFeed()
+	case 'M':
+		if s.y == s.top {
+			s.scrollDown(1)
+		} else {
+			s.y = max(s.y-1, 0)
+		}
+	case 'c':
+		s.pen = blankCell
+		s.clear(0, len(s.cells))
+		s.x, s.y = 0, 0
+		s.top, s.bot = 0, s.rows-1
+	}
+	return 2
+}
+
+// osc interprets an operating system command, e.g. setting the window title.
+func (s *screen) osc(buf []byte) int {
+	for i := 2; i < len(buf); i++ {
+		var n int
+		switch {
+		case buf[i] == '\a':
+			n = i + 1
+		case buf[i] == '\033' && i+1 < len(buf) && buf[i+1] == '\\':
+			n = i + 2
+		case buf[i] == '\033' && i+1 == len(buf):
+			return 0
+		default:
+			continue
+		}
+		if ps, text, ok := strings.Cut(string(buf[2:i]), ";"); ok && (ps == "0" || ps == "2") {
+			s.title = text
+		}
+		return n
+	}
+	return 0
+}
+
+// csi interprets a control sequence introducer sequence.
+func (s *screen) csi(buf []byte) int {
+	end := -1
+	for i := 2; i < len(buf); i++ {
+		if c := buf[i]; c >= 0x40 && c <= 0x7e {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return 0
+	}
+	final := buf[end]
+	params := string(buf[2:end])
+	if strings.HasPrefix(params, "?") || strings.HasPrefix(params, ">") {
+		// Private modes (cursor visibility etc.) don't affect the buffer.
+		return end + 1
+	}
+	args := parseParams(params)
+	arg := func(i, def int) int {
+		if i < len(args) && args[i] > 0 {
+			return args[i]
+		}
+		return def
+	}
+	switch final {
+	case 'H', 'f':
+		s.y = min(arg(0, 1), s.rows) - 1
+		s.x = min(arg(1, 1), s.cols) - 1
+	case 'A':
+		s.y = max(s.y-arg(0, 1), 0)
+	case 'B', 'e':
+		s.y = min(s.y+arg(0, 1), s.rows-1)
+	case 'C', 'a':
+		s.x = min(s.x+arg(0, 1), s.cols-1)
+	case 'D':
+		s.x = max(s.x-arg(0, 1), 0)
+	case 'G', '`':
+		s.x = min(arg(0, 1), s.cols) - 1
+	case 'd':
+		s.y = min(arg(0, 1), s.rows) - 1
+	case 'J':
+		i := s.y*s.cols + min(s.x, s.cols-1)
+		switch arg(0, 0) {
+		case 0:
+			s.clear(i, len(s.cells))
+		case 1:
+			s.clear(0, i+1)
+		default:
+			s.clear(0, len(s.cells))
+		}
+	case 'K':
+		row := s.y * s.cols
+		i := row + min(s.x, s.cols-1)
+		switch arg(0, 0) {
+		case 0:
+			s.clear(i, row+s.cols)
+		case 1:
+			s.clear(row, i+1)
+		default:
+			s.clear(row, row+s.cols)
+		}
+	case 'L':
+		if s.y >= s.top && s.y <= s.bot {
+			s.insertLines(s.y, arg(0, 1))
+		}
+	case 'M':
+		if s.y >= s.top && s.y <= s.bot {
+			s.deleteLines(s.y, arg(0, 1))
+		}
+	case 'S':
+		s.scrollUp(arg(0, 1))
+	case 'T':
+		s.scrollDown(arg(0, 1))
+	case 'r':
+		top, bot := arg(0, 1)-1, min(arg(1, s.rows), s.rows)-1
+		if top < bot {
+			s.top, s.bot = top, bot
+		}
+		s.x, s.y = 0, 0
+	case 'm':
+		s.sgr(args)
+	case 's':
+		s.savedX, s.savedY = s.x, s.y
+	case 'u':
+		s.x, s.y = s.savedX, s.savedY
+	}
+	return end + 1
+}
+
+func parseParams(params string) []int {
+	if params == "" {
+		return nil
+	}
+	fields := strings.Split(params, ";")
+	args := make([]int, len(fields))
+	for i, f := range fields {
+		args[i], _ = strconv.Atoi(f)
+	}
+	return args
+}
+
+// sgr applies select graphic rendition parameters to the pen.
+func (s *screen) sgr(args []int) {
+	if len(args) == 0 {
+		args = []int{0}
+	}
+	for _, a := range args {
+		switch {
+		case a == 0:
+			s.pen = blankCell
+		case a == 1:
+			s.pen.Attr |= AttrBold
+		case a == 4:
+			s.pen.Attr |= AttrUnderline
+		case a == 5:
+			s.pen.Attr |= AttrBlink
+		case a == 7:
+			s.pen.Attr |= AttrReverse
+		case a == 22:
+			s.pen.Attr &^= AttrBold
+		case a == 24:
+			s.pen.Attr &^= AttrUnderline
+		case a == 25:
+			s.pen.Attr &^= AttrBlink
+		case a == 27:
+			s.pen.Attr &^= AttrReverse
+		case a >= 30 && a <= 37:
+			s.pen.FG = Color(a - 30)
+		case a == 39:
+			s.pen.FG = ColorDefault
+		case a >= 40 && a <= 47:
+			s.pen.BG = Color(a - 40)
+		case a == 49:
+			s.pen.BG = ColorDefault
+		}
+	}
+}
+
+// lines returns the screen text, one string per row with trailing spaces trimmed.
+func (s *screen) lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lines := make([]string, s.rows)
+	var sb strings.Builder
+	for y := range lines {
+		sb.Reset()
+		for _, c := range s.cells[y*s.cols : (y+1)*s.cols] {
+			sb.WriteRune(c.Rune)
+		}
+		lines[y] = strings.TrimRight(sb.String(), " ")
+	}
+	return lines
+}
+
+func (s *screen) cell(x, y int) Cell {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if x < 0 || x >= s.cols || y < 0 || y >= s.rows {
+		return blankCell
+	}
+	return s.cells[y*s.cols+x]
+}
+
+// Screen returns the text of the rendered terminal screen, one line per row.
+// The screen reflects all output read from links2 so far.
+func (b *Browser) Screen() string {
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.screenText()
+}
+
+func (b *Browser) screenText() string {
+	if b.scr == nil {
+		return ""
+	}
+	return strings.Join(b.scr.lines(), "\n")
+}
+
+// StatusBar returns the text of the status bar, the last row of the screen,
+// once pending output is read. The status bar shows the target of the
+// selected link, the load phase while loading and some errors.
+func (b *Browser) StatusBar() (string, error) {
+	return b.StatusBarContext(context.Background())
+}
+
+// StatusBarContext is like StatusBar but bounds waits by ctx.
+func (b *Browser) StatusBarContext(ctx context.Context) (string, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	if b.c == nil {
+		return "", ErrNotStarted
+	}
+	if _, err := b.drain(); err != nil {
+		return "", err
+	}
+	return b.statusBar(), nil
+}
+
+// statusBar returns the text of the status bar, the last row of the screen.
+func (b *Browser) statusBar() string {
+	lines := b.scr.lines()
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// Cell returns the cell at column x and row y of the rendered terminal screen.
+// Positions off the screen are blank.
+func (b *Browser) Cell(x, y int) Cell {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.scr == nil {
+		return blankCell
+	}
+	return b.scr.cell(x, y)
+}
+
+// highlight returns the row and text of the first reverse video run at or
+// below row minRow, or -1 if there is none.
+func (s *screen) highlight(minRow int) (row int, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for y := max(minRow, 0); y < s.rows; y++ {
+		var sb strings.Builder
+		for _, c := range s.cells[y*s.cols : (y+1)*s.cols] {
+			if c.Attr&AttrReverse != 0 {
+				sb.WriteRune(c.Rune)
+			} else if sb.Len() > 0 {
+				break
+			}
+		}
+		if text := strings.TrimSpace(sb.String()); text != "" {
+			return y, text
+		}
+	}
+	return -1, ""
+}
+
+// attrRuns returns the runs of cells with attribute a in rows from to to,
+// inclusive, in reading order.
+func (s *screen) attrRuns(a Attr, from, to int) []Highlight {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		runs []Highlight
+		sb   strings.Builder
+	)
+	for y := max(from, 0); y <= min(to, s.rows-1); y++ {
+		x0 := -1
+		for x := 0; x <= s.cols; x++ {
+			if x < s.cols && s.cells[y*s.cols+x].Attr&a != 0 {
+				if x0 < 0 {
+					x0 = x
+					sb.Reset()
+				}
+				sb.WriteRune(s.cells[y*s.cols+x].Rune)
+				continue
+			}
+			if x0 >= 0 {
+				runs = append(runs, Highlight{X: x0, Y: y, Width: x - x0, Text: sb.String()})
+				x0 = -1
+			}
+		}
+	}
+	return runs
+}
@@ -0,0 +1,97 @@
+package links2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScreen(t *testing.T) {
+	tests := []struct {
+		name       string
+		cols, rows int
+		out        string
+		want       []string
+		x, y       int
+	}{
+		{"text", 5, 2, "ab", []string{"ab", ""}, 2, 0},
+		{"CR LF", 5, 3, "ab\r\ncd", []string{"ab", "cd", ""}, 2, 1},
+		{"CUP", 5, 3, "\033[2;3Hx", []string{"", "  x", ""}, 3, 1},
+		{"CUP default", 5, 2, "ab\033[Hx", []string{"xb", ""}, 1, 0},
+		{"CUP clamped", 3, 2, "\033[9;9Hx", []string{"", "  x"}, 3, 1},
+		{"ED below", 3, 3, "abc\r\ndef\r\nghi\033[2;2H\033[J", []string{"abc", "d", ""}, 1, 1},
+		{"ED above", 3, 3, "abc\r\ndef\r\nghi\033[2;2H\033[1J", []string{"", "  f", "ghi"}, 1, 1},
+		{"ED all", 3, 2, "abc\r\ndef\033[2J", []string{"", ""}, 3, 1},
+		{"EL right", 3, 2, "abc\033[1;2H\033[K", []string{"a", ""}, 1, 0},
+		{"EL left", 3, 2, "abc\033[1;2H\033[1K", []string{"  c", ""}, 1, 0},
+		{"EL line", 3, 2, "abc\033[1;2H\033[2K", []string{"", ""}, 1, 0},
+		{"wrap", 3, 2, "abcd", []string{"abc", "d"}, 1, 1},
+		{"wrap scrolls", 2, 2, "abcde", []string{"cd", "e"}, 1, 1},
+		{"last column", 3, 2, "abc", []string{"abc", ""}, 3, 0},
+		{"LF scrolls", 3, 2, "a\r\nb\r\nc", []string{"b", "c"}, 1, 1},
+		{"scroll region", 3, 4, "a\r\nb\r\nc\r\nd\033[2;3r\033[3;1H\n", []string{"a", "c", "", "d"}, 0, 2},
+		{"scroll region SU", 3, 4, "a\r\nb\r\nc\r\nd\033[2;3r\033[S", []string{"a", "c", "", "d"}, 0, 0},
+		{"scroll region SD", 3, 4, "a\r\nb\r\nc\r\nd\033[2;3r\033[T", []string{"a", "", "b", "d"}, 0, 0},
+		{"reverse index", 3, 3, "a\r\nb\033[H\033M", []string{"", "a", "b"}, 0, 0},
+		{"DECSC DECRC", 5, 2, "ab\0337\033[2;4Hx\0338y", []string{"aby", "   x"}, 3, 0},
+		{"SCOSC SCORC", 5, 2, "ab\033[s\033[2;4Hx\033[uy", []string{"aby", "   x"}, 3, 0},
+		{"split sequence", 5, 2, "a\033[2", []string{"a", ""}, 1, 0},
+		{"DEC graphics", 3, 1, "\033(0lqk\033(B", []string{"┌─┐"}, 3, 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newScreen(tc.cols, tc.rows)
+			s.Write([]byte(tc.out))
+			if got := s.lines(); strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Errorf("lines = %q, want %q", got, tc.want)
+			}
+			if s.x != tc.x || s.y != tc.y {
+				t.Errorf("cursor = %d,%d, want %d,%d", s.x, s.y, tc.x, tc.y)
+			}
+		})
+	}
+}
+
+func TestScreenSplitWrites(t *testing.T) {
+	const out = "\033[2;2H\033[7mé\033[0m\033]0;title\a"
+	s := newScreen(4, 2)
+	for i := range len(out) {
+		s.Write([]byte(out[i : i+1]))
+	}
+	if got := s.lines(); got[1] != " é" {
+		t.Errorf("lines = %q", got)
+	}
+	if c := s.cell(1, 1); c.Attr != AttrReverse {
+		t.Errorf("cell attr = %v, want reverse", c.Attr)
+	}
+	if s.title != "title" {
+		t.Errorf("title = %q", s.title)
+	}
+}
+
+func TestScreenResize(t *testing.T) {
+	tests := []struct {
+		name       string
+		out        string
+		cols, rows int
+		after      string
+		want       []string
+	}{
+		{"shrink", "abcd\r\nefgh\r\nijkl", 2, 2, "", []string{"ab", "ef"}},
+		{"grow", "ab\r\ncd", 3, 3, "", []string{"ab", "cd", ""}},
+		{"cursor clamped", "\033[3;4H", 2, 2, "x", []string{"", " x"}},
+		{"DECRC clamped", "\033[3;4H\0337", 2, 2, "\0338x", []string{"", " x"}},
+		{"SCORC clamped", "\033[3;4H\033[s", 2, 2, "\033[ux", []string{"", " x"}},
+		{"scroll region reset", "\033[1;2r", 2, 4, "\033[4;1H\na", []string{"", "", "", "a"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newScreen(4, 3)
+			s.Write([]byte(tc.out))
+			s.resize(tc.cols, tc.rows)
+			s.Write([]byte(tc.after))
+			if got := s.lines(); strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Errorf("lines = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
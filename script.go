@@ -0,0 +1,264 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RunScript runs the actions of a script read from r, stopping at the first
+// error. A script is a sequence of s-expressions, one action each, with
+// double quoted string arguments; ";" starts a comment:
+//
+//	; Log in and save the dashboard.
+//	(navigate "https://example.com/login")
+//	(fill "user" "alice" "pass" "secret")
+//	(submit)
+//	(wait-for "Dashboard")
+//	(follow-link "Reports")
+//	(save-as "reports.txt")
+//
+// The actions are:
+//
+//	(navigate URL)              Navigate
+//	(back)                      BackLink
+//	(reload)                    Reload
+//	(wait-for TEXT [SECONDS])   WaitForText, 30 seconds by default
+//	(wait-for-regexp RE [SECONDS])
+//	(follow-link TEXT)          FollowLinkMatching with LinkText
+//	(follow-link-url RE)        FollowLinkMatching with LinkURLMatches
+//	(search TEXT)               SearchFor
+//	(type TEXT)                 TypeText
+//	(fill NAME VALUE ...)       FillForm
+//	(submit)                    SubmitForm
+//	(save-as FILE)              SaveFormattedDocument, replacing FILE
+//	(save-source FILE)          WriteSource to FILE
+func (b *Browser) RunScript(r io.Reader) error {
+	return b.RunScriptContext(context.Background(), r)
+}
+
+// RunScriptContext is like RunScript but bounds waits by ctx.
+func (b *Browser) RunScriptContext(ctx context.Context, r io.Reader) error {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	actions, err := parseScript(string(src))
+	if err != nil {
+		return err
+	}
+	for _, a := range actions {
+		if err := b.runAction(ctx, a); err != nil {
+			return fmt.Errorf("script: line %d: %s: %w", a.line, a.name, err)
+		}
+	}
+	return nil
+}
+
+// scriptAction is a parsed script action.
+type scriptAction struct {
+	line int
+	name string
+	args []string
+}
+
+// parseScript parses the s-expressions of a script.
+func parseScript(src string) ([]scriptAction, error) {
+	var (
+		actions []scriptAction
+		cur     *scriptAction
+		line    = 1
+	)
+	for i := 0; i < len(src); {
+		switch c := src[i]; {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == ';':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '(':
+			if cur != nil {
+				return nil, fmt.Errorf("script: line %d: nested expression", line)
+			}
+			cur = &scriptAction{line: line}
+			i++
+		case c == ')':
+			if cur == nil {
+				return nil, fmt.Errorf("script: line %d: unexpected )", line)
+			}
+			if cur.name == "" {
+				return nil, fmt.Errorf("script: line %d: empty expression", line)
+			}
+			actions = append(actions, *cur)
+			cur = nil
+			i++
+		case cur == nil:
+			return nil, fmt.Errorf("script: line %d: expected (", line)
+		case c == '"':
+			j := i + 1
+			for ; j < len(src) && src[j] != '"'; j++ {
+				if src[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("script: line %d: unterminated string", line)
+			}
+			s, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("script: line %d: invalid string: %v", line, err)
+			}
+			if cur.name == "" {
+				return nil, fmt.Errorf("script: line %d: expected action name", line)
+			}
+			cur.args = append(cur.args, s)
+			line += strings.Count(src[i:j+1], "\n")
+			i = j + 1
+		default:
+			j := i
+			for j < len(src) && !strings.ContainsRune(" \t\r\n();\"", rune(src[j])) {
+				j++
+			}
+			if cur.name == "" {
+				cur.name = src[i:j]
+			} else {
+				cur.args = append(cur.args, src[i:j])
+			}
+			i = j
+		}
+	}
+	if cur != nil {
+		return nil, fmt.Errorf("script: line %d: missing )", line)
+	}
+	return actions, nil
+}
+
+// defaultScriptWait bounds wait-for actions without a timeout.
+const defaultScriptWait = 30 * time.Second
+
+func (b *Browser) runAction(ctx context.Context, a scriptAction) error {
+	nargs := func(min, max int) error {
+		if len(a.args) < min || len(a.args) > max {
+			return fmt.Errorf("wrong number of arguments: %d", len(a.args))
+		}
+		return nil
+	}
+	wait := func(f func(ctx context.Context) error) error {
+		if err := nargs(1, 2); err != nil {
+			return err
+		}
+		d := defaultScriptWait
+		if len(a.args) == 2 {
+			secs, err := strconv.ParseFloat(a.args[1], 64)
+			if err != nil {
+				return fmt.Errorf("invalid timeout: %q", a.args[1])
+			}
+			d = time.Duration(secs * float64(time.Second))
+		}
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return f(ctx)
+	}
+	switch a.name {
+	case "navigate":
+		if err := nargs(1, 1); err != nil {
+			return err
+		}
+		_, err := b.NavigateContext(ctx, a.args[0])
+		return err
+	case "back":
+		if err := nargs(0, 0); err != nil {
+			return err
+		}
+		_, err := b.BackLinkContext(ctx)
+		return err
+	case "reload":
+		if err := nargs(0, 0); err != nil {
+			return err
+		}
+		return b.ReloadContext(ctx)
+	case "wait-for":
+		return wait(func(ctx context.Context) error { return b.WaitForText(ctx, a.args[0]) })
+	case "wait-for-regexp":
+		if err := nargs(1, 2); err != nil {
+			return err
+		}
+		re, err := regexp.Compile(a.args[0])
+		if err != nil {
+			return err
+		}
+		return wait(func(ctx context.Context) error { return b.WaitForRegexp(ctx, re) })
+	case "follow-link":
+		if err := nargs(1, 1); err != nil {
+			return err
+		}
+		return b.FollowLinkMatchingContext(ctx, LinkText(a.args[0]))
+	case "follow-link-url":
+		if err := nargs(1, 1); err != nil {
+			return err
+		}
+		re, err := regexp.Compile(a.args[0])
+		if err != nil {
+			return err
+		}
+		return b.FollowLinkMatchingContext(ctx, LinkURLMatches(re))
+	case "search":
+		if err := nargs(1, 1); err != nil {
+			return err
+		}
+		found, err := b.SearchForContext(ctx, a.args[0])
+		if err == nil && !found {
+			err = fmt.Errorf("%q not found", a.args[0])
+		}
+		return err
+	case "type":
+		if err := nargs(1, 1); err != nil {
+			return err
+		}
+		return b.TypeText(a.args[0])
+	case "fill":
+		if len(a.args) == 0 || len(a.args)%2 != 0 {
+			return fmt.Errorf("want name value pairs")
+		}
+		values := make(map[string]string, len(a.args)/2)
+		for i := 0; i < len(a.args); i += 2 {
+			values[a.args[i]] = a.args[i+1]
+		}
+		return b.FillFormContext(ctx, values)
+	case "submit":
+		if err := nargs(0, 0); err != nil {
+			return err
+		}
+		_, err := b.SubmitFormContext(ctx)
+		return err
+	case "save-as":
+		if err := nargs(1, 1); err != nil {
+			return err
+		}
+		return b.SaveFormattedDocumentContext(ctx, a.args[0], true)
+	case "save-source":
+		if err := nargs(1, 1); err != nil {
+			return err
+		}
+		f, err := os.Create(a.args[0])
+		if err != nil {
+			return err
+		}
+		if err := b.WriteSourceContext(ctx, f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	default:
+		return fmt.Errorf("unknown action")
+	}
+}
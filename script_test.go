@@ -0,0 +1,95 @@
+package links2
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseScript(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      []scriptAction
+	}{
+		{"empty", "", nil},
+		{"comments", "; nothing\n  ; here\n", nil},
+		{"bare", "(back)(reload)", []scriptAction{{line: 1, name: "back"}, {line: 1, name: "reload"}}},
+		{
+			"arguments",
+			"; Log in.\n(navigate \"https://example.com/login\")\n(fill \"user\" \"alice\" pass \"s e c\")\n\t(wait-for \"Dash\" 2.5) ; inline\n",
+			[]scriptAction{
+				{line: 2, name: "navigate", args: []string{"https://example.com/login"}},
+				{line: 3, name: "fill", args: []string{"user", "alice", "pass", "s e c"}},
+				{line: 4, name: "wait-for", args: []string{"Dash", "2.5"}},
+			},
+		},
+		{
+			"escapes",
+			`(type "say \"hi\"\n\t" "a;b(c)")`,
+			[]scriptAction{{line: 1, name: "type", args: []string{"say \"hi\"\n\t", "a;b(c)"}}},
+		},
+		{
+			"multiline",
+			"(fill\n  \"a\" \"1\"\n  \"b\" \"2\")\n(submit)",
+			[]scriptAction{
+				{line: 1, name: "fill", args: []string{"a", "1", "b", "2"}},
+				{line: 4, name: "submit"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseScript(tc.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseScript = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseScriptError(t *testing.T) {
+	tests := []struct{ src, err string }{
+		{"back", "line 1: expected ("},
+		{"(back", "line 1: missing )"},
+		{")", "line 1: unexpected )"},
+		{"()", "line 1: empty expression"},
+		{"(a (b))", "line 1: nested expression"},
+		{"\n(type \"open)", "line 2: unterminated string"},
+		{`("x")`, "line 1: expected action name"},
+		{`(type "\q")`, "line 1: invalid string"},
+	}
+	for _, tc := range tests {
+		_, err := parseScript(tc.src)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("parseScript(%q) = %v, want error %q", tc.src, err, tc.err)
+		}
+	}
+}
+
+func TestRunActionArguments(t *testing.T) {
+	tests := []struct {
+		action scriptAction
+		err    string
+	}{
+		{scriptAction{name: "dance"}, "unknown action"},
+		{scriptAction{name: "navigate"}, "wrong number of arguments: 0"},
+		{scriptAction{name: "back", args: []string{"x"}}, "wrong number of arguments: 1"},
+		{scriptAction{name: "wait-for", args: []string{"a", "b", "c"}}, "wrong number of arguments: 3"},
+		{scriptAction{name: "wait-for", args: []string{"a", "soon"}}, `invalid timeout: "soon"`},
+		{scriptAction{name: "wait-for-regexp", args: []string{"("}}, "missing closing )"},
+		{scriptAction{name: "follow-link-url", args: []string{"["}}, "missing closing ]"},
+		{scriptAction{name: "fill", args: []string{"a"}}, "want name value pairs"},
+		{scriptAction{name: "fill"}, "want name value pairs"},
+	}
+	b := &Browser{}
+	for _, tc := range tests {
+		err := b.runAction(context.Background(), tc.action)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("runAction(%+v) = %v, want error %q", tc.action, err, tc.err)
+		}
+	}
+}
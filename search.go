@@ -0,0 +1,104 @@
+package links2
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	searchDialog   = "Search \033[0;7m"
+	searchNotFound = "Search string not found"
+)
+
+// SearchFor searches forward in the document for term, scrolling to and
+// highlighting the first match. It returns false if there is no match.
+func (b *Browser) SearchFor(term string) (bool, error) {
+	return b.SearchForContext(context.Background(), term)
+}
+
+// SearchForContext is like SearchFor but bounds waits by ctx.
+func (b *Browser) SearchForContext(ctx context.Context, term string) (bool, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.searchFor(ActionSearch, menuSearch, term)
+}
+
+// SearchBackwardFor is like SearchFor but searches backward in the document.
+func (b *Browser) SearchBackwardFor(term string) (bool, error) {
+	return b.SearchBackwardForContext(context.Background(), term)
+}
+
+// SearchBackwardForContext is like SearchBackwardFor but bounds waits by ctx.
+func (b *Browser) SearchBackwardForContext(ctx context.Context, term string) (bool, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.searchFor(ActionSearchBackward, menuRSearch, term)
+}
+
+func (b *Browser) searchFor(a Action, menu, term string) (bool, error) {
+	if err := checkInput(term); err != nil {
+		return false, err
+	}
+	if err := b.perform(a); err != nil {
+		return false, err
+	}
+	b.s = stateMenu
+	b.menuName = menu
+	if _, err := b.expectString(searchDialog); err != nil {
+		return false, b.keymapError(a, err)
+	}
+	b.c.Send(term + "\n")
+	b.s = stateIdle
+	b.menuName = ""
+	raw, err := b.drain()
+	if err != nil {
+		return false, err
+	}
+	if strings.Contains(raw, b.tr(searchNotFound)) {
+		// Leave the message box for closeMenu.
+		b.s = stateMenu
+		b.menuName = menuError
+		return false, nil
+	}
+	return true, nil
+}
+
+// Highlight is a run of highlighted cells on the screen.
+type Highlight struct {
+	X, Y  int // X and Y are the column and row of the first cell.
+	Width int
+	Text  string
+}
+
+// SearchHighlights returns the matches of the last search highlighted on the
+// screen, in reading order, read from the cell attributes of the screen.
+// Links2 draws matches in reverse video like the selected link, so a link
+// selected on screen is reported too.
+func (b *Browser) SearchHighlights() ([]Highlight, error) {
+	return b.SearchHighlightsContext(context.Background())
+}
+
+// SearchHighlightsContext is like SearchHighlights but bounds waits by ctx.
+func (b *Browser) SearchHighlightsContext(ctx context.Context) ([]Highlight, error) {
+	_, done := b.begin(ctx)
+	defer done()
+	if err := b.closeMenu(); err != nil {
+		return nil, err
+	}
+	if _, err := b.drain(); err != nil {
+		return nil, err
+	}
+	// The first and last rows hold the title and status bars.
+	return b.scr.attrRuns(AttrReverse, 1, b.scr.rows-2), nil
+}
+
+// ClearSearch clears the search term and its highlighted matches.
+func (b *Browser) ClearSearch() error {
+	return b.ClearSearchContext(context.Background())
+}
+
+// ClearSearchContext is like ClearSearch but bounds waits by ctx.
+func (b *Browser) ClearSearchContext(ctx context.Context) error {
+	_, err := b.SearchForContext(ctx, "")
+	return err
+}
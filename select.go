@@ -0,0 +1,41 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SelectText selects the rows fromLine to toLine of the rendered screen,
+// counted from 0 and inclusive, as a copy would. The selected text is kept
+// for SelectedText until the next selection, so the page may be scrolled
+// or left afterwards.
+func (b *Browser) SelectText(fromLine, toLine int) error {
+	return b.SelectTextContext(context.Background(), fromLine, toLine)
+}
+
+// SelectTextContext is like SelectText but bounds waits by ctx.
+func (b *Browser) SelectTextContext(ctx context.Context, fromLine, toLine int) error {
+	_, done := b.begin(ctx)
+	defer done()
+	if b.c == nil {
+		return ErrNotStarted
+	}
+	if _, err := b.drain(); err != nil {
+		return err
+	}
+	lines := b.scr.lines()
+	if fromLine < 0 || toLine < fromLine || toLine >= len(lines) {
+		return fmt.Errorf("select text: invalid lines %d to %d of %d", fromLine, toLine, len(lines))
+	}
+	b.selection = strings.Join(lines[fromLine:toLine+1], "\n")
+	return nil
+}
+
+// SelectedText returns the text selected by SelectText, or "" if there is
+// none.
+func (b *Browser) SelectedText() string {
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.selection
+}
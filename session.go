@@ -0,0 +1,88 @@
+package links2
+
+import (
+	"context"
+	"strings"
+)
+
+// Session is a snapshot of the browser state which can be replayed into
+// another Browser with RestoreSession.
+type Session struct {
+	URL        string         // URL of the current document.
+	History    []HistoryEntry // History is the back history, most recent first.
+	ViewSource bool           // ViewSource is set when viewing the document source.
+	TopLine    string         // TopLine is the first line of text on screen, marking the scroll position.
+}
+
+// SaveSession captures the current URL, back history, view-source state and
+// scroll position.
+func (b *Browser) SaveSession() (Session, error) {
+	return b.SaveSessionContext(context.Background())
+}
+
+// SaveSessionContext is like SaveSession but bounds waits by ctx.
+func (b *Browser) SaveSessionContext(ctx context.Context) (Session, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	history, err := b.HistoryContext(ctx)
+	if err != nil {
+		return Session{}, err
+	}
+	info, err := b.DocumentInfoContext(ctx)
+	if err != nil {
+		return Session{}, err
+	}
+	return Session{
+		URL:        info.URL,
+		History:    history,
+		ViewSource: b.viewSource,
+		TopLine:    b.topLine(),
+	}, nil
+}
+
+// topLine returns the first line of document text on screen.
+// The first and last rows hold the title and status bars.
+func (b *Browser) topLine() string {
+	lines := b.scr.lines()
+	for _, line := range lines[1 : len(lines)-1] {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// RestoreSession replays s into the browser, usually a freshly opened one.
+//
+// The back history is rebuilt by navigating to each entry, oldest first,
+// before the current URL. The scroll position is restored by searching for
+// the saved top line, so it is approximate.
+func (b *Browser) RestoreSession(s Session) error {
+	return b.RestoreSessionContext(context.Background(), s)
+}
+
+// RestoreSessionContext is like RestoreSession but bounds waits by ctx.
+func (b *Browser) RestoreSessionContext(ctx context.Context, s Session) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	for i := len(s.History) - 1; i >= 0; i-- {
+		if _, err := b.NavigateContext(ctx, s.History[i].URL); err != nil {
+			return err
+		}
+	}
+	if s.URL != "" {
+		if _, err := b.NavigateContext(ctx, s.URL); err != nil {
+			return err
+		}
+	}
+	if err := b.setViewSource(s.ViewSource); err != nil {
+		return err
+	}
+	if s.TopLine != "" {
+		if _, err := b.SearchForContext(ctx, s.TopLine); err != nil {
+			return err
+		}
+		return b.ClearSearchContext(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package links2
+
+import "context"
+
+// Labels of the Setup→Terminal options dialog.
+const (
+	labelNoFrames    = "No frames"
+	labelVT100Frames = "VT 100 frames"
+	labelColor       = "Color"
+	labelUTF8IO      = "UTF-8 I/O"
+)
+
+// TerminalSettings are the rendering settings of the Setup→Terminal options
+// dialog. Setting them all makes the screen independent of ~/.links.
+type TerminalSettings struct {
+	Frames bool // Frames draws VT100 line drawing frames instead of none.
+	Colors bool
+	UTF8   bool // UTF8 makes links2 read and write UTF-8.
+}
+
+// SetTerminalSettings sets the terminal options of the running links2.
+// Use SaveSettings to persist them.
+func (b *Browser) SetTerminalSettings(ts TerminalSettings) error {
+	return b.SetTerminalSettingsContext(context.Background(), ts)
+}
+
+// SetTerminalSettingsContext is like SetTerminalSettings but bounds waits by ctx.
+func (b *Browser) SetTerminalSettingsContext(ctx context.Context, ts TerminalSettings) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.openDialog(ctx, "Setup", "Terminal options"); err != nil {
+		return err
+	}
+	frames := labelNoFrames
+	if ts.Frames {
+		frames = labelVT100Frames
+	}
+	return b.setControls(map[string]bool{
+		frames:      true,
+		labelColor:  ts.Colors,
+		labelUTF8IO: ts.UTF8,
+	})
+}
+
+// SaveSettings saves the current settings to ~/.links (Setup→Save options).
+func (b *Browser) SaveSettings() error {
+	return b.SaveSettingsContext(context.Background())
+}
+
+// SaveSettingsContext is like SaveSettings but bounds waits by ctx.
+func (b *Browser) SaveSettingsContext(ctx context.Context) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.OpenMenuContext(ctx, "Setup", "Save options"); err != nil {
+		return err
+	}
+	b.s = stateIdle
+	b.menuName = ""
+	return nil
+}
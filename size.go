@@ -0,0 +1,55 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithSize sets the size of the pseudo-terminal links2 renders to.
+// By default the PTY reports no size and links2 assumes 80x25.
+func WithSize(cols, rows int) Option {
+	return func(o *options) error {
+		if cols <= 0 || rows <= 0 {
+			return fmt.Errorf("invalid terminal size: %dx%d", cols, rows)
+		}
+		o.cols, o.rows = cols, rows
+		return nil
+	}
+}
+
+// SetSize resizes the pseudo-terminal and signals links2 to redraw at the new size.
+func (b *Browser) SetSize(cols, rows int) error {
+	_, done := b.begin(context.Background())
+	defer done()
+	if cols <= 0 || rows <= 0 {
+		return fmt.Errorf("invalid terminal size: %dx%d", cols, rows)
+	}
+	if b.s == stateUndefined {
+		return ErrNotStarted
+	}
+	if tty := b.c.Tty(); tty != nil {
+		if err := setWinsize(tty, cols, rows); err != nil {
+			return err
+		}
+	}
+	b.scr.mu.Lock()
+	b.scr.resize(cols, rows)
+	b.scr.mu.Unlock()
+	b.cast.resize(cols, rows)
+	if b.cmd == nil {
+		return nil
+	}
+	return signalWinch(b.cmd.Process)
+}
+
+// Size returns the size of the terminal links2 renders to.
+func (b *Browser) Size() (cols, rows int) {
+	_, done := b.begin(context.Background())
+	defer done()
+	if b.scr == nil {
+		return 0, 0
+	}
+	b.scr.mu.Lock()
+	defer b.scr.mu.Unlock()
+	return b.scr.cols, b.scr.rows
+}
@@ -0,0 +1,14 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package links2
+
+import (
+	"errors"
+	"os"
+)
+
+var errResizeUnsupported = errors.New("terminal resize not supported on this platform")
+
+func setWinsize(tty *os.File, cols, rows int) error { return errResizeUnsupported }
+
+func signalWinch(p *os.Process) error { return errResizeUnsupported }
@@ -0,0 +1,26 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package links2
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is struct winsize from <sys/ioctl.h>.
+type winsize struct {
+	rows, cols     uint16
+	xpixel, ypixel uint16
+}
+
+func setWinsize(tty *os.File, cols, rows int) error {
+	ws := winsize{rows: uint16(rows), cols: uint16(cols)}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, tty.Fd(), uintptr(syscall.TIOCSWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return os.NewSyscallError("ioctl TIOCSWINSZ", errno)
+	}
+	return nil
+}
+
+func signalWinch(p *os.Process) error { return p.Signal(syscall.SIGWINCH) }
@@ -0,0 +1,83 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+)
+
+// State is the state of a Browser as tracked by its operations: whether
+// links2 is started and whether a menu or dialog is open.
+type State int
+
+// The values match those of the unexported state.
+const (
+	StateClosed  State = iota // StateClosed the Browser is not open, or was closed.
+	StateStarted              // StateStarted links2 was started and may show a welcome screen.
+	StateIdle                 // StateIdle no menus or dialogs are open.
+	StateMenu                 // StateMenu a menu or dialog is open, named by OpenMenuName.
+	StateDirty                // StateDirty the screen was changed by Raw and must be resynced.
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateStarted:
+		return "started"
+	case StateIdle:
+		return "idle"
+	case StateMenu:
+		return "menu"
+	case StateDirty:
+		return "dirty"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// State returns the state of the Browser, waiting for an operation in
+// progress to finish. It's the state the Browser believes links2 is in;
+// Resync corrects it when it has drifted from the screen.
+func (b *Browser) State() State {
+	_, done := b.begin(context.Background())
+	defer done()
+	return State(b.s)
+}
+
+// OpenMenuName returns the name of the menu or dialog open in StateMenu,
+// e.g. "dropdown", "info" or a menu path like "File/Save as", and "" if
+// none is open.
+func (b *Browser) OpenMenuName() string {
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.menuName
+}
+
+// Resync brings the state of the Browser back in line with the screen, for
+// when keys it didn't send or a missed redraw left them apart. It opens the
+// dropdown menu, which also dismisses a menu or dialog left open, and closes
+// it again, leaving links2 idle.
+func (b *Browser) Resync() error {
+	return b.ResyncContext(context.Background())
+}
+
+// ResyncContext is like Resync but bounds waits by ctx.
+func (b *Browser) ResyncContext(ctx context.Context) error {
+	_, done := b.begin(ctx)
+	defer done()
+	if b.s == stateUndefined {
+		return ErrNotStarted
+	}
+	if err := b.checkExited(); err != nil {
+		return err
+	}
+	if b.s == stateStarted {
+		// The welcome screen is dismissed like a menu.
+		if err := b.closeMenu(); err != nil {
+			return err
+		}
+	}
+	b.log.Debug("resync", "state", State(b.s), "menu", b.menuName)
+	b.s = stateDirty
+	b.menuName = ""
+	return b.resync()
+}
@@ -0,0 +1,75 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNavigationAborted is returned by a navigation stopped by StopLoading.
+var ErrNavigationAborted = errors.New("navigation aborted")
+
+// loadAbort lets StopLoading reach a page load in progress. It has its own
+// lock since the loading operation holds the Browser's.
+type loadAbort struct {
+	mu      sync.Mutex
+	c       Console
+	keys    string        // keys abort the load.
+	wait    time.Duration // wait bounds how long links2 may take to stop.
+	cancel  context.CancelCauseFunc
+	timer   *time.Timer
+	aborted bool
+}
+
+// StopLoading aborts the page load in progress, if any, as the stop key of
+// links2 does. The pending navigation returns an error wrapping
+// ErrNavigationAborted once links2 stopped, or after the Menu timeout if it
+// doesn't. Unlike other methods, StopLoading may be called while another
+// operation is in progress.
+func (b *Browser) StopLoading() error {
+	l := &b.load
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cancel == nil || l.aborted {
+		return nil
+	}
+	l.aborted = true
+	cancel := l.cancel
+	l.timer = time.AfterFunc(l.wait, func() { cancel(ErrNavigationAborted) })
+	_, err := l.c.Send(l.keys)
+	return err
+}
+
+// startLoad makes the load in progress abortable by StopLoading. It returns
+// the context to bound the load by.
+func (b *Browser) startLoad(ctx context.Context) context.Context {
+	keys, err := b.keys(ActionStop)
+	if err != nil {
+		return ctx
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	l := &b.load
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.c, l.keys, l.wait, l.cancel, l.aborted = b.c, keys, b.timeouts.Menu, cancel, false
+	return ctx
+}
+
+// finishLoad ends the load started by startLoad and reports whether it was
+// aborted.
+func (b *Browser) finishLoad() bool {
+	l := &b.load
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cancel == nil {
+		return false
+	}
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.cancel(nil)
+	aborted := l.aborted
+	l.c, l.cancel, l.timer, l.aborted = nil, nil, nil, false
+	return aborted
+}
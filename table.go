@@ -0,0 +1,170 @@
+package links2
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Tables returns the tables of the current document in document order, each
+// as rows of cells, parsed from the source. Cells are the text of td and th
+// elements with spaces collapsed. A cell spanning several columns or rows
+// is followed by, or above, empty cells so the columns of each table line up.
+// Nested tables are returned separately and left out of their cell.
+func (b *Browser) Tables() ([][][]string, error) {
+	return b.TablesContext(context.Background())
+}
+
+// TablesContext is like Tables but bounds waits by ctx.
+func (b *Browser) TablesContext(ctx context.Context) ([][][]string, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	var src bytes.Buffer
+	if err := b.writeSaved(&src, func(path string) error { return b.saveSource(ctx, path) }); err != nil {
+		return nil, err
+	}
+	return tables(src.String()), nil
+}
+
+// maxSpan bounds colspan and rowspan, which tables use to pad cells.
+const maxSpan = 1000
+
+// tableBuilder collects the rows of a table being parsed.
+type tableBuilder struct {
+	index int // index is the position of the table in the result.
+	rows  [][]string
+	row   []string
+	inRow bool
+	cell  *strings.Builder // cell is the text of the open cell, if any.
+	col   int              // col is the column of the open cell.
+	spans []int            // spans are the rows still covered in each column.
+}
+
+func (t *tableBuilder) startRow() {
+	t.endRow()
+	t.row, t.inRow = nil, true
+}
+
+// pad appends the cells covered by rowspans from earlier rows.
+func (t *tableBuilder) pad() {
+	for col := len(t.row); col < len(t.spans) && t.spans[col] > 0; col++ {
+		t.spans[col]--
+		t.row = append(t.row, "")
+	}
+}
+
+func (t *tableBuilder) startCell(attrs map[string]string) {
+	if !t.inRow {
+		t.startRow()
+	}
+	t.endCell()
+	t.pad()
+	colspan, rowspan := span(attrs["colspan"]), span(attrs["rowspan"])
+	col := len(t.row)
+	t.row = append(t.row, "")
+	for i := 1; i < colspan; i++ {
+		t.row = append(t.row, "")
+	}
+	for len(t.spans) < len(t.row) {
+		t.spans = append(t.spans, 0)
+	}
+	for i := col; i < col+colspan; i++ {
+		t.spans[i] = rowspan - 1
+	}
+	t.cell, t.col = new(strings.Builder), col
+}
+
+func (t *tableBuilder) endCell() {
+	if t.cell == nil {
+		return
+	}
+	t.row[t.col] = strings.Join(strings.Fields(t.cell.String()), " ")
+	t.cell = nil
+}
+
+func (t *tableBuilder) endRow() {
+	t.endCell()
+	if !t.inRow {
+		return
+	}
+	t.pad()
+	if len(t.row) > 0 {
+		t.rows = append(t.rows, t.row)
+	}
+	t.row, t.inRow = nil, false
+}
+
+// span parses a colspan or rowspan attribute, which defaults to 1.
+func span(s string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return min(n, maxSpan)
+}
+
+// tables returns the tables of an HTML document.
+func tables(src string) [][][]string {
+	var (
+		result [][][]string
+		open   []*tableBuilder
+		skip   int
+	)
+	for _, tok := range tokenize(src) {
+		var t *tableBuilder
+		if n := len(open); n > 0 {
+			t = open[n-1]
+		}
+		switch tok.kind {
+		case startToken:
+			switch {
+			case skipTags[tok.tag]:
+				skip++
+			case tok.tag == "table":
+				open = append(open, &tableBuilder{index: len(result)})
+				result = append(result, nil)
+			case t == nil:
+			case tok.tag == "tr":
+				t.startRow()
+			case tok.tag == "td" || tok.tag == "th":
+				t.startCell(tok.attrs)
+			case (tok.tag == "br" || blockTags[tok.tag]) && t.cell != nil:
+				t.cell.WriteByte(' ')
+			}
+		case endToken:
+			switch {
+			case skipTags[tok.tag]:
+				if skip > 0 {
+					skip--
+				}
+			case t == nil:
+			case tok.tag == "table":
+				t.endRow()
+				result[t.index] = t.rows
+				open = open[:len(open)-1]
+			case tok.tag == "tr":
+				t.endRow()
+			case tok.tag == "td" || tok.tag == "th":
+				t.endCell()
+			}
+		case textToken:
+			if t != nil && t.cell != nil && skip == 0 {
+				t.cell.WriteString(tok.text)
+			}
+		}
+	}
+	// Unclosed tables end with the document.
+	for _, t := range open {
+		t.endRow()
+		result[t.index] = t.rows
+	}
+	// Tables without rows, e.g. used for layout only, are dropped.
+	tables := result[:0]
+	for _, rows := range result {
+		if len(rows) > 0 {
+			tables = append(tables, rows)
+		}
+	}
+	return tables
+}
@@ -0,0 +1,81 @@
+package links2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTables(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      [][][]string
+	}{
+		{"none", "<p>no tables</p>", nil},
+		{"empty", "<table></table>", [][][]string{}},
+		{
+			"simple",
+			"<table><tr><th>Name</th><th>Age</th></tr><tr><td> Alice\n Smith </td><td>30</td></tr></table>",
+			[][][]string{{{"Name", "Age"}, {"Alice Smith", "30"}}},
+		},
+		{
+			"implied rows and cells",
+			"<table><tbody><tr><td>a<td>b<tr><td>c<td>d</table>",
+			[][][]string{{{"a", "b"}, {"c", "d"}}},
+		},
+		{
+			"cell without row",
+			"<table><td>a</td></table>",
+			[][][]string{{{"a"}}},
+		},
+		{
+			"colspan",
+			`<table><tr><td colspan="2">wide</td><td>x</td></tr><tr><td>a</td><td>b</td><td>c</td></tr></table>`,
+			[][][]string{{{"wide", "", "x"}, {"a", "b", "c"}}},
+		},
+		{
+			"rowspan",
+			`<table><tr><td rowspan="2">tall</td><td>a</td></tr><tr><td>b</td></tr><tr><td>c</td><td>d</td></tr></table>`,
+			[][][]string{{{"tall", "a"}, {"", "b"}, {"c", "d"}}},
+		},
+		{
+			"invalid spans",
+			`<table><tr><td colspan="0">a</td><td rowspan="x">b</td></tr></table>`,
+			[][][]string{{{"a", "b"}}},
+		},
+		{
+			"nested",
+			"<table><tr><td>outer<table><tr><td>inner</td></tr></table></td><td>2</td></tr></table>",
+			[][][]string{{{"outer", "2"}}, {{"inner"}}},
+		},
+		{
+			"markup in cells",
+			"<table><tr><td><b>bold</b><br>line<script>x()</script></td></tr></table>",
+			[][][]string{{{"bold line"}}},
+		},
+		{
+			"unclosed",
+			"<table><tr><td>a",
+			[][][]string{{{"a"}}},
+		},
+		{
+			"two tables",
+			"<table><tr><td>1</td></tr></table><p>text</p><table><tr><td>2</td></tr></table>",
+			[][][]string{{{"1"}}, {{"2"}}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tables(tc.src); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("tables(%q) = %q, want %q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSpan(t *testing.T) {
+	for s, want := range map[string]int{"": 1, "2": 2, " 3 ": 3, "0": 1, "-1": 1, "x": 1, "100000": maxSpan} {
+		if got := span(s); got != want {
+			t.Errorf("span(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Tabs is a set of Browsers used like the tabs of a graphical browser.
+// Links2 has no tabs, so each tab is a Browser of its own, opened with the
+// options shared by all tabs. One tab is current.
+//
+// Tabs is safe for concurrent use.
+type Tabs struct {
+	opts   []Option
+	events chan TabEvent
+	wg     sync.WaitGroup // wg tracks the goroutines forwarding events.
+
+	mu      sync.Mutex
+	tabs    []*Browser
+	current int // current is the index of the current tab, or -1.
+	closed  bool
+}
+
+// TabEvent is an Event of a tab.
+type TabEvent struct {
+	Tab *Browser
+	Event
+}
+
+// NewTabs returns an empty set of tabs which opens each tab with opts.
+func NewTabs(opts ...Option) *Tabs {
+	return &Tabs{opts: opts, events: make(chan TabEvent, eventBuffer), current: -1}
+}
+
+// NewTab opens a new tab after the others, makes it current and returns it.
+func (t *Tabs) NewTab(ctx context.Context) (*Browser, error) {
+	b := new(Browser)
+	if err := b.OpenContext(ctx, t.opts...); err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		b.Close()
+		return nil, fmt.Errorf("tabs closed")
+	}
+	t.tabs = append(t.tabs, b)
+	t.current = len(t.tabs) - 1
+	events := b.Events()
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		for e := range events {
+			select {
+			case t.events <- TabEvent{Tab: b, Event: e}:
+			default:
+			}
+		}
+	}()
+	return b, nil
+}
+
+// Len returns the number of tabs.
+func (t *Tabs) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.tabs)
+}
+
+// Tab returns tab i, or nil if there is no such tab.
+func (t *Tabs) Tab(i int) *Browser {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i < 0 || i >= len(t.tabs) {
+		return nil
+	}
+	return t.tabs[i]
+}
+
+// Current returns the current tab, or nil if there are no tabs.
+func (t *Tabs) Current() *Browser {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current < 0 {
+		return nil
+	}
+	return t.tabs[t.current]
+}
+
+// Switch makes tab i current.
+func (t *Tabs) Switch(i int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i < 0 || i >= len(t.tabs) {
+		return fmt.Errorf("switch tab: no tab %d of %d", i, len(t.tabs))
+	}
+	t.current = i
+	return nil
+}
+
+// CloseTab closes tab i. The tabs after it move down by one. Closing the
+// current tab makes the tab before it current, if any.
+func (t *Tabs) CloseTab(i int) error {
+	t.mu.Lock()
+	if i < 0 || i >= len(t.tabs) {
+		n := len(t.tabs)
+		t.mu.Unlock()
+		return fmt.Errorf("close tab: no tab %d of %d", i, n)
+	}
+	b := t.tabs[i]
+	t.tabs = append(t.tabs[:i], t.tabs[i+1:]...)
+	if t.current >= i {
+		t.current--
+	}
+	if t.current < 0 && len(t.tabs) > 0 {
+		t.current = 0
+	}
+	t.mu.Unlock()
+	return b.Close()
+}
+
+// Events returns the channel of events of all tabs. The channel is closed
+// by Close. Events are dropped if the channel is not drained.
+func (t *Tabs) Events() <-chan TabEvent { return t.events }
+
+// Close closes all tabs.
+func (t *Tabs) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	tabs := t.tabs
+	t.tabs, t.current = nil, -1
+	t.mu.Unlock()
+	var first error
+	for _, b := range tabs {
+		if err := b.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	// Closing a Browser closes its event channel, ending its forwarder.
+	t.wg.Wait()
+	close(t.events)
+	return first
+}
@@ -0,0 +1,135 @@
+package links2
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dialogLines extracts the lines of text drawn by raw terminal output.
+//
+// Links2 draws each line of a dialog after positioning the cursor, so cursor
+// positioning sequences (and CR/LF) start a new line. All other escape
+// sequences are dropped, as are the ASCII frame characters around the text.
+// Empty lines and lines made only of frame characters are omitted.
+func dialogLines(raw string) []string {
+	var (
+		lines []string
+		sb    strings.Builder
+	)
+	flush := func() {
+		if line := trimFrame(sb.String()); line != "" {
+			lines = append(lines, line)
+		}
+		sb.Reset()
+	}
+	for i := 0; i < len(raw); {
+		switch c := raw[i]; c {
+		case '\033':
+			n, final := escapeLen(raw[i:])
+			if final == 'H' || final == 'f' {
+				flush()
+			}
+			i += n
+		case '\r', '\n':
+			flush()
+			i++
+		default:
+			r, n := utf8.DecodeRuneInString(raw[i:])
+			if r >= ' ' && r != utf8.RuneError {
+				sb.WriteRune(r)
+			}
+			i += n
+		}
+	}
+	flush()
+	return lines
+}
+
+// escapeLen returns the length of the escape sequence at the start of s
+// along with its final byte. CSI sequences are ESC [ params final, charset
+// selection is ESC ( c, and anything else is treated as a 2-byte sequence.
+func escapeLen(s string) (n int, final byte) {
+	if len(s) < 2 {
+		return len(s), 0
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; c >= 0x40 && c <= 0x7e {
+				return i + 1, c
+			}
+		}
+		return len(s), 0
+	case '(', ')':
+		if len(s) < 3 {
+			return len(s), 0
+		}
+		return 3, s[2]
+	default:
+		return 2, s[1]
+	}
+}
+
+// trimFrame trims the ASCII frame drawn around dialog text.
+func trimFrame(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSpace(strings.Trim(s, "|"))
+	if strings.Trim(s, "+-| ") == "" {
+		return ""
+	}
+	return s
+}
+
+// reverseVideo is the attribute links2 uses to draw the selected link.
+const reverseVideo = "\033[0;7m"
+
+// highlightedText returns the last run of text drawn in reverse video by raw
+// terminal output, or "" if there is none.
+func highlightedText(raw string) string {
+	i := strings.LastIndex(raw, reverseVideo)
+	if i < 0 {
+		return ""
+	}
+	s := raw[i+len(reverseVideo):]
+	if j := strings.IndexByte(s, '\033'); j >= 0 {
+		s = s[:j]
+	}
+	return strings.TrimSpace(s)
+}
+
+// checkInput returns an error wrapping ErrUnsafeInput if s is not safe to
+// type into links2. Links2 interprets control characters, including ESC and
+// the C1 controls some terminals treat as escape introducers, as key presses.
+// Format characters such as bidi overrides and line or paragraph separators
+// are rejected too, since they would garble the dialog as it is redrawn.
+func checkInput(s string) error {
+	if !utf8.ValidString(s) {
+		return fmt.Errorf("%w: not a valid unicode string: %q", ErrUnsafeInput, s)
+	}
+	for _, r := range s {
+		if unsafeRune(r) {
+			return fmt.Errorf("%w: contains control characters: %q", ErrUnsafeInput, s)
+		}
+	}
+	return nil
+}
+
+// unsafeRune reports whether r must not be typed into links2.
+func unsafeRune(r rune) bool {
+	return r == utf8.RuneError || unicode.IsControl(r) ||
+		unicode.In(r, unicode.Cf, unicode.Zl, unicode.Zp)
+}
+
+// sanitizeInput replaces the runes of s which checkInput rejects with '_'.
+// It is used for text links2 suggests itself, like file names taken from the
+// document URL, which cannot be rejected the way caller input is.
+func sanitizeInput(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unsafeRune(r) {
+			return '_'
+		}
+		return r
+	}, strings.ToValidUTF8(s, "_"))
+}
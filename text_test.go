@@ -0,0 +1,43 @@
+package links2
+
+import (
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// checkSafe fails t if s holds a rune which must not be typed into links2.
+func checkSafe(t *testing.T, s string) {
+	t.Helper()
+	if !utf8.ValidString(s) {
+		t.Fatalf("accepted invalid UTF-8: %q", s)
+	}
+	for _, r := range s {
+		switch {
+		case r < 0x20, r >= 0x7f && r <= 0x9f: // C0 including ESC, CR and LF, DEL and C1
+			t.Fatalf("accepted control %U: %q", r, s)
+		case unicode.In(r, unicode.Cf, unicode.Zl, unicode.Zp):
+			t.Fatalf("accepted format or separator %U: %q", r, s)
+		case r == utf8.RuneError:
+			t.Fatalf("accepted replacement character: %q", s)
+		}
+	}
+}
+
+func FuzzCheckInput(f *testing.F) {
+	for _, s := range []string{
+		"", "http://example.com/", "hello world", "\033[A", "a\rb", "a\nb",
+		"\u009b2J", "‮evil", "a b", " ", "\xff", "héllo", "日本語",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if checkInput(s) == nil {
+			checkSafe(t, s)
+		}
+		sanitized := sanitizeInput(s)
+		if err := checkInput(sanitized); err != nil {
+			t.Fatalf("sanitizeInput(%q) = %q: %v", s, sanitized, err)
+		}
+	})
+}
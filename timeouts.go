@@ -0,0 +1,120 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Netflix/go-expect"
+)
+
+// Timeouts bounds how long the Browser waits for links2 to produce output.
+// Each timeout applies to a period without output, so a slow trickle of
+// output keeps a wait going.
+type Timeouts struct {
+	Open     time.Duration // Open is how long to watch for the welcome screen of a newly opened browser.
+	Navigate time.Duration // Navigate bounds waiting for a page load to finish.
+	Menu     time.Duration // Menu bounds waiting for a menu or dialog to open.
+	Dialog   time.Duration // Dialog is how long to watch for a dialog which may not appear, like "File already exists".
+}
+
+// DefaultTimeouts are the timeouts used by a Browser unless overridden by WithTimeouts.
+var DefaultTimeouts = Timeouts{
+	Open:     500 * time.Millisecond,
+	Navigate: time.Minute,
+	Menu:     5 * time.Second,
+	Dialog:   500 * time.Millisecond,
+}
+
+// WithTimeouts overrides the DefaultTimeouts. Zero fields keep their default.
+func WithTimeouts(t Timeouts) Option {
+	return func(o *options) error {
+		if t.Open > 0 {
+			o.timeouts.Open = t.Open
+		}
+		if t.Navigate > 0 {
+			o.timeouts.Navigate = t.Navigate
+		}
+		if t.Menu > 0 {
+			o.timeouts.Menu = t.Menu
+		}
+		if t.Dialog > 0 {
+			o.timeouts.Dialog = t.Dialog
+		}
+		return nil
+	}
+}
+
+// opKey marks the context of an operation holding the Browser lock.
+type opKey struct{}
+
+// begin starts an operation: it takes the Browser lock and makes ctx bound
+// expect waits until the returned func is called. Operations call it first:
+//
+//	ctx, done := b.begin(ctx)
+//	defer done()
+//
+// The returned context is marked so that operations calling other operations
+// with it don't try to take the lock again.
+func (b *Browser) begin(ctx context.Context) (context.Context, func()) {
+	if ctx.Value(opKey{}) == b {
+		prev := b.ctx
+		b.ctx = ctx
+		return ctx, func() { b.ctx = prev }
+	}
+	b.mu.Lock()
+	ctx = context.WithValue(ctx, opKey{}, b)
+	b.ctx = ctx
+	return ctx, func() {
+		b.ctx = nil
+		b.mu.Unlock()
+	}
+}
+
+// send sends keys while idle as a single operation.
+func (b *Browser) send(keys string) error {
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.sendIdle(keys)
+}
+
+// expect waits for opts to match the output, bounded by timeout without
+// output and by the operation context. It returns the output consumed.
+func (b *Browser) expect(timeout time.Duration, opts ...expect.ExpectOpt) (string, error) {
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var eo expect.ExpectOpts
+	for _, opt := range opts {
+		if err := opt(&eo); err != nil {
+			return "", err
+		}
+	}
+	criteria := make([]any, len(eo.Matchers))
+	for i, m := range eo.Matchers {
+		criteria[i] = m.Criteria()
+	}
+	buf, err := b.reader.match(ctx, timeout, eo)
+	if err != nil {
+		b.log.Debug("expect miss", "criteria", criteria, "read", len(buf), "err", err)
+		if m := b.metrics(); m != nil && errors.Is(err, ErrTimeout) {
+			m.Timeout()
+		}
+		return buf, err
+	}
+	b.log.Debug("expect match", "criteria", criteria, "read", len(buf))
+	return buf, nil
+}
+
+// expectString waits for s to open, bounded by the Menu timeout.
+func (b *Browser) expectString(s string) (string, error) {
+	return b.expect(b.timeouts.Menu, b.match(s))
+}
+
+// expectDialog watches for a dialog which may not appear, bounded by the
+// Dialog timeout, and reports whether it appeared.
+func (b *Browser) expectDialog(s string) bool {
+	_, err := b.expect(b.timeouts.Dialog, b.match(s))
+	return err == nil
+}
@@ -0,0 +1,33 @@
+package links2
+
+import (
+	"context"
+	"strings"
+)
+
+// Title returns the title of the current document as shown in the top bar,
+// falling back to the Info dialog when the bar is empty, e.g. because it's
+// too narrow.
+func (b *Browser) Title() (string, error) {
+	return b.TitleContext(context.Background())
+}
+
+// TitleContext is like Title but bounds waits by ctx.
+func (b *Browser) TitleContext(ctx context.Context) (string, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if err := b.closeMenu(); err != nil {
+		return "", err
+	}
+	if _, err := b.drain(); err != nil {
+		return "", err
+	}
+	if title := strings.TrimSpace(b.scr.lines()[0]); title != "" {
+		return title, nil
+	}
+	info, err := b.DocumentInfoContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.Title, nil
+}
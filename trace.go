@@ -0,0 +1,74 @@
+package links2
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer starts spans for browser operations, e.g. by adapting an
+// OpenTelemetry trace.Tracer:
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, start time.Time) (context.Context, links2.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name, trace.WithTimestamp(start))
+//		return ctx, otelSpan{span}
+//	}
+//
+// Spans are started for Open, Navigate, SaveFormattedDocument and Quit.
+// Navigate spans have a child span for each load phase observed.
+type Tracer interface {
+	// Start starts a span as a child of any span in ctx and returns a
+	// context holding the new span.
+	Start(ctx context.Context, name string, start time.Time) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SetAttribute(key string, value any)
+	// End ends the span, recording err if it's not nil.
+	End(end time.Time, err error)
+}
+
+// WithTracer traces browser operations with t.
+func WithTracer(t Tracer) Option {
+	return func(o *options) error {
+		o.tracer = t
+		return nil
+	}
+}
+
+// nopSpan is the Span used when there's no Tracer.
+type nopSpan struct{}
+
+func (nopSpan) SetAttribute(string, any) {}
+func (nopSpan) End(time.Time, error)     {}
+
+// startSpan starts a span with t, if set.
+func startSpan(t Tracer, ctx context.Context, name string) (context.Context, Span) {
+	if t == nil {
+		return ctx, nopSpan{}
+	}
+	return t.Start(ctx, name, time.Now())
+}
+
+// startSpan starts a span with the Tracer of WithTracer, if any.
+func (b *Browser) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if b.opts == nil {
+		return ctx, nopSpan{}
+	}
+	return startSpan(b.opts.tracer, ctx, name)
+}
+
+// tracePhases records a span for each load phase of res as children of ctx.
+func (b *Browser) tracePhases(ctx context.Context, res NavigateResult) {
+	if b.opts == nil || b.opts.tracer == nil {
+		return
+	}
+	for i, e := range res.Phases {
+		end := res.End
+		if i+1 < len(res.Phases) {
+			end = res.Phases[i+1].Time
+		}
+		_, span := b.opts.tracer.Start(ctx, "links2.phase "+e.Phase.String(), e.Time)
+		span.End(end, nil)
+	}
+}
@@ -0,0 +1,87 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a browser version as printed by its -version flag.
+type Version struct {
+	Major, Minor, Patch int
+	Text                string // Text is the first line of the -version output, e.g. "Links 2.29".
+}
+
+// Known reports whether the version was detected.
+func (v Version) Known() bool { return v.Text != "" }
+
+// AtLeast reports whether v is major.minor or later. Unknown versions are
+// assumed to be recent, so AtLeast returns true for them.
+func (v Version) AtLeast(major, minor int) bool {
+	if !v.Known() {
+		return true
+	}
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+func (v Version) String() string {
+	if !v.Known() {
+		return "unknown"
+	}
+	return v.Text
+}
+
+// Version returns the version of the browser detected when it was opened.
+// The version is unknown if it could not be detected or the browser is not
+// started.
+func (b *Browser) Version() Version {
+	_, done := b.begin(context.Background())
+	defer done()
+	return b.version
+}
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// parseVersion parses the output of a browser's -version flag.
+func parseVersion(out string) (Version, bool) {
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	m := versionPattern.FindStringSubmatch(line)
+	if m == nil {
+		return Version{}, false
+	}
+	v := Version{Text: strings.TrimSpace(line)}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	v.Patch, _ = strconv.Atoi(m[3])
+	return v, true
+}
+
+// detectVersion runs the browser of o with -version, bounded by the Open
+// timeout. It returns the zero Version if detection fails.
+func detectVersion(ctx context.Context, o *options) Version {
+	ctx, cancel := context.WithTimeout(ctx, o.timeouts.Open)
+	defer cancel()
+	name, args := o.driver.Command()
+	out, err := exec.CommandContext(ctx, name, append(args[:len(args):len(args)], "-version")...).Output()
+	if err != nil && len(out) == 0 {
+		return Version{}
+	}
+	v, _ := parseVersion(string(out))
+	return v
+}
+
+// checkVersion returns an error wrapping errors.ErrUnsupported if feature
+// needs a version of links2 later than the detected one.
+func checkVersion(v Version, feature string, major, minor int) error {
+	if v.AtLeast(major, minor) {
+		return nil
+	}
+	return fmt.Errorf("%s needs links %d.%d, have %s: %w", feature, major, minor, v, errors.ErrUnsupported)
+}
@@ -0,0 +1,67 @@
+package links2
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		out  string
+		want Version
+		ok   bool
+	}{
+		{"Links 2.29\n", Version{Major: 2, Minor: 29, Text: "Links 2.29"}, true},
+		{"  Links 2.1pre41\nmore\n", Version{Major: 2, Minor: 1, Text: "Links 2.1pre41"}, true},
+		{"ELinks 0.16.1.1\nBuilt on ...", Version{Major: 0, Minor: 16, Patch: 1, Text: "ELinks 0.16.1.1"}, true},
+		{"Lynx Version 2.9.0dev.12 (2023)", Version{Major: 2, Minor: 9, Patch: 0, Text: "Lynx Version 2.9.0dev.12 (2023)"}, true},
+		{"", Version{}, false},
+		{"links: unknown option\nLinks 2.29", Version{}, false},
+	}
+	for _, tc := range tests {
+		got, ok := parseVersion(tc.out)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("parseVersion(%q) = %+v, %v, want %+v, %v", tc.out, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	v := Version{Major: 2, Minor: 14, Text: "Links 2.14"}
+	tests := []struct {
+		v            Version
+		major, minor int
+		want         bool
+	}{
+		{v, 2, 14, true},
+		{v, 2, 3, true},
+		{v, 1, 99, true},
+		{v, 2, 15, false},
+		{v, 3, 0, false},
+		{Version{}, 99, 0, true}, // Unknown versions are assumed to be recent.
+	}
+	for _, tc := range tests {
+		if got := tc.v.AtLeast(tc.major, tc.minor); got != tc.want {
+			t.Errorf("%v.AtLeast(%d, %d) = %v, want %v", tc.v, tc.major, tc.minor, got, tc.want)
+		}
+	}
+}
+
+func TestVersionString(t *testing.T) {
+	if s := (Version{}).String(); s != "unknown" {
+		t.Errorf("unknown version String() = %q", s)
+	}
+	if s := (Version{Major: 2, Minor: 29, Text: "Links 2.29"}).String(); s != "Links 2.29" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	v := Version{Major: 1, Minor: 3, Text: "Links 1.03"}
+	if err := checkVersion(v, "graphics mode", 2, 0); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("checkVersion(1.3, 2.0) = %v, want ErrUnsupported", err)
+	}
+	if err := checkVersion(v, "graphics mode", 1, 0); err != nil {
+		t.Errorf("checkVersion(1.3, 1.0) = %v", err)
+	}
+}
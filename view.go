@@ -0,0 +1,80 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// minSourceRows is how many document rows must show markup for the
+// document to be taken as shown as source.
+const minSourceRows = 3
+
+// sourceTag matches an HTML tag or doctype as shown by the source view.
+var sourceTag = regexp.MustCompile(`<(?:[!/]?[a-zA-Z][a-zA-Z0-9-]*)(?:\s[^<>]*)?/?>`)
+
+// ViewSource shows the source of the current document (\) and waits for
+// links2 to redraw it. The current mode is derived from the screen, so
+// ViewSource does nothing if the source is already shown.
+func (b *Browser) ViewSource() error { return b.ViewSourceContext(context.Background()) }
+
+// ViewSourceContext is like ViewSource but bounds waits by ctx.
+func (b *Browser) ViewSourceContext(ctx context.Context) error {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.setViewSource(true)
+}
+
+// ViewHTML shows the current document rendered, like ViewSource.
+func (b *Browser) ViewHTML() error { return b.ViewHTMLContext(context.Background()) }
+
+// ViewHTMLContext is like ViewHTML but bounds waits by ctx.
+func (b *Browser) ViewHTMLContext(ctx context.Context) error {
+	_, done := b.begin(ctx)
+	defer done()
+	return b.setViewSource(false)
+}
+
+// setViewSource switches to the source view if on is set and otherwise to
+// the rendered view. The view is toggled only if the screen doesn't already
+// show it, and the switch is complete once the document is redrawn. A
+// document which looks the same either way, e.g. plain text, is taken as
+// switched when no redraw follows within the Dialog timeout.
+func (b *Browser) setViewSource(on bool) error {
+	if err := b.closeMenu(); err != nil {
+		return err
+	}
+	if _, err := b.drain(); err != nil {
+		return err
+	}
+	b.viewSource = b.sourceShown()
+	if b.viewSource == on {
+		return nil
+	}
+	before := strings.Join(b.documentRows(), "\n")
+	if err := b.perform(ActionViewSource); err != nil {
+		return err
+	}
+	err := b.awaitScreen(b.ctx, b.timeouts.Dialog, func(string) bool {
+		return strings.Join(b.documentRows(), "\n") != before
+	})
+	if err != nil && !errors.Is(err, ErrTimeout) {
+		return fmt.Errorf("view source: %w", err)
+	}
+	b.viewSource = on
+	return nil
+}
+
+// sourceShown reports whether the screen shows the source of an HTML
+// document, judged by the rows showing markup.
+func (b *Browser) sourceShown() bool {
+	n := 0
+	for _, row := range b.documentRows() {
+		if sourceTag.MatchString(row) {
+			n++
+		}
+	}
+	return n >= minSourceRows
+}
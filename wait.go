@@ -0,0 +1,83 @@
+package links2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// pollInterval is how long links2 must be quiet for drain, and how often
+// waits on files poll.
+const pollInterval = 50 * time.Millisecond
+
+// WaitForText blocks until the rendered screen contains text or ctx is done.
+// Close and Quit interrupt the wait, which then returns ErrClosed.
+func (b *Browser) WaitForText(ctx context.Context, text string) error {
+	return b.waitFor(ctx, func(screen string) bool { return strings.Contains(screen, text) })
+}
+
+// WaitForRegexp blocks until the rendered screen matches re or ctx is done,
+// and is interrupted like WaitForText.
+func (b *Browser) WaitForRegexp(ctx context.Context, re *regexp.Regexp) error {
+	return b.waitFor(ctx, re.MatchString)
+}
+
+// waitFor waits until cond holds for the rendered screen or ctx is done.
+func (b *Browser) waitFor(ctx context.Context, cond func(screen string) bool) error {
+	ctx, done := b.begin(ctx)
+	defer done()
+	if b.c == nil {
+		return ErrNotStarted
+	}
+	return b.awaitScreen(ctx, 0, cond)
+}
+
+// awaitScreen waits until cond holds for the rendered screen, bounded by
+// timeout, unless it's zero, and by ctx, which may be nil. Close and Quit
+// interrupt the wait with ErrClosed. cond is checked again whenever output
+// changed the screen.
+func (b *Browser) awaitScreen(ctx context.Context, timeout time.Duration, cond func(screen string) bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	var (
+		last    string
+		checked bool
+	)
+	for {
+		_, err, changed := b.reader.state()
+		if screen := b.screenText(); !checked || screen != last {
+			if cond(screen) {
+				b.reader.consumeAll()
+				return nil
+			}
+			last, checked = screen, true
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-changed:
+		case <-expired:
+			return fmt.Errorf("%w: %w", ErrTimeout, os.ErrDeadlineExceeded)
+		case <-b.closing.wait():
+			return ErrClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// drain waits until links2 is quiet for pollInterval and returns the output.
+func (b *Browser) drain() (string, error) {
+	return b.reader.drain(pollInterval)
+}
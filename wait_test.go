@@ -0,0 +1,48 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// testCloseInterrupts checks that Close returns while wait, started first,
+// waits for the screen, and that the wait then fails with ErrClosed.
+func testCloseInterrupts(t *testing.T, wait func(b *Browser) error) {
+	var (
+		fake *fakeConsole
+		b    Browser
+	)
+	if err := b.Open(withFakeConsole(&fake)); err != nil {
+		t.Fatal(err)
+	}
+	waited := make(chan error, 1)
+	go func() { waited <- wait(&b) }()
+	time.Sleep(100 * time.Millisecond) // Let the wait take the lock.
+	closed := make(chan error, 1)
+	go func() { closed <- b.Close() }()
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close blocked by a pending wait")
+	}
+	if err := <-waited; !errors.Is(err, ErrClosed) {
+		t.Errorf("wait = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestCloseInterruptsWaitForText(t *testing.T) {
+	testCloseInterrupts(t, func(b *Browser) error {
+		return b.WaitForText(context.Background(), "never shown")
+	})
+}
+
+func TestCloseInterruptsWaitUntil(t *testing.T) {
+	testCloseInterrupts(t, func(b *Browser) error {
+		return b.WaitUntil(context.Background(), Any(TextVisible("never shown"), PageLoaded()))
+	})
+}
@@ -0,0 +1,86 @@
+package links2
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Change is a change of a document watched by Watch.
+type Change struct {
+	URL     string
+	Time    time.Time
+	Changes []LineChange // Changes are the lines changed since the last check.
+	Err     error        // Err is set if the document could not be checked.
+}
+
+// Watch navigates to rawURL and then again every interval, sending a Change
+// whenever the formatted document differs from the one seen before, or an
+// attempt failed. Changes are also emitted as EventDocumentChange events.
+//
+// The first navigation is made before Watch returns and its error returned.
+// The channel is closed once ctx is done or the browser is closed. Other
+// operations may be used between checks, but leave the watched page.
+func (b *Browser) Watch(ctx context.Context, rawURL string, interval time.Duration) (<-chan Change, error) {
+	last, err := b.fingerprintURL(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan Change)
+	go func() {
+		defer close(ch)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				return
+			}
+			c, changed, err := b.checkWatched(ctx, rawURL, &last)
+			if errors.Is(err, ErrNotStarted) || ctx.Err() != nil {
+				return
+			}
+			if !changed {
+				continue
+			}
+			select {
+			case ch <- c:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// checkWatched navigates to rawURL and compares the document to last as
+// one operation, updating last. It reports whether a Change should be sent,
+// which is emitted as an event too.
+func (b *Browser) checkWatched(ctx context.Context, rawURL string, last *Fingerprint) (Change, bool, error) {
+	ctx, done := b.begin(ctx)
+	defer done()
+	c := Change{URL: rawURL}
+	fp, err := b.fingerprintURL(ctx, rawURL)
+	switch {
+	case err != nil:
+		c.Err = err
+	case fp.Sum == last.Sum:
+		return c, false, nil
+	default:
+		c.Changes = diffLines(last.lines, fp.lines)
+		*last = fp
+	}
+	c.Time = time.Now()
+	b.events.emit(Event{Kind: EventDocumentChange, Time: c.Time, URL: rawURL, Err: c.Err})
+	return c, true, c.Err
+}
+
+// fingerprintURL navigates to rawURL and returns the Fingerprint of the
+// document.
+func (b *Browser) fingerprintURL(ctx context.Context, rawURL string) (Fingerprint, error) {
+	if _, err := b.NavigateContext(ctx, rawURL); err != nil {
+		return Fingerprint{}, err
+	}
+	return b.FingerprintContext(ctx)
+}